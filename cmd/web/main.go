@@ -1,39 +1,16 @@
 package main
 
 import (
-	"fmt"
-	"snack-store-api/internal/cache"
+	"os"
 	"snack-store-api/internal/command"
 	"snack-store-api/internal/config"
+	_ "time/tzdata"
 )
 
 func main() {
 	viperConfig := config.NewViper()
 	log := config.NewLogger(viperConfig)
-	db := config.NewDatabase(viperConfig, log)
-	redisClient := config.NewRedis(viperConfig)
-	cacheClient := cache.NewRedisCache(redisClient)
-	executor := command.NewCommandExecutor(viperConfig, db)
-	validate := config.NewValidator()
-	router := config.NewGin(log)
+	executor := command.NewCommandExecutor(viperConfig, log)
 
-	config.Bootstrap(&config.BootstrapConfig{
-		DB:       db,
-		Router:   router,
-		Log:      log,
-		Validate: validate,
-		Viper:    viperConfig,
-		Cache:    cacheClient,
-		Redis:    redisClient,
-	})
-
-	if !executor.Execute(log) {
-		return
-	}
-
-	webPort := viperConfig.GetInt("PORT")
-	err := router.Run(fmt.Sprintf(":%d", webPort))
-	if err != nil {
-		log.Fatalf("Failed to start server: %v", err)
-	}
+	os.Exit(executor.Execute(os.Args[1:]))
 }
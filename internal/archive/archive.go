@@ -0,0 +1,280 @@
+package archive
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+// FormatVersion is bumped when the archive layout changes incompatibly.
+const FormatVersion = 1
+
+const ManifestName = "manifest.json"
+
+// Tables lists the archived tables in foreign key order; import restores
+// them in this order.
+var Tables = []string{"customers", "products", "shifts", "transactions", "redemptions", "receipt_sequences"}
+
+type Manifest struct {
+	FormatVersion int          `json:"format_version"`
+	SchemaVersion int64        `json:"schema_version"`
+	CreatedAt     time.Time    `json:"created_at"`
+	Tables        []TableEntry `json:"tables"`
+	Totals        Totals       `json:"totals"`
+}
+
+type TableEntry struct {
+	Name   string `json:"name"`
+	File   string `json:"file"`
+	Rows   int64  `json:"rows"`
+	SHA256 string `json:"sha256"`
+}
+
+// Totals are recomputed after an import to verify it.
+type Totals struct {
+	CustomerPoints int64 `json:"customer_points"`
+	ProductStock   int64 `json:"product_stock"`
+	PointsEarned   int64 `json:"points_earned"`
+	PointsSpent    int64 `json:"points_spent"`
+}
+
+// CheckTables fails unless the manifest lists exactly Tables, in order, so an
+// archive from another layout is rejected before anything is written.
+func (m *Manifest) CheckTables() error {
+	names := make([]string, 0, len(m.Tables))
+	for _, entry := range m.Tables {
+		names = append(names, entry.Name)
+	}
+	if !slices.Equal(names, Tables) {
+		return fmt.Errorf("archive tables %v do not match the expected %v", names, Tables)
+	}
+	return nil
+}
+
+// CheckTotals compares totals recomputed from the imported rows with the ones
+// recorded at export and names every total that differs.
+func (m *Manifest) CheckTotals(totals Totals) error {
+	var differences []string
+	compare := func(name string, expected, actual int64) {
+		if expected != actual {
+			differences = append(differences, fmt.Sprintf("%s %d, expected %d", name, actual, expected))
+		}
+	}
+	compare("customer_points", m.Totals.CustomerPoints, totals.CustomerPoints)
+	compare("product_stock", m.Totals.ProductStock, totals.ProductStock)
+	compare("points_earned", m.Totals.PointsEarned, totals.PointsEarned)
+	compare("points_spent", m.Totals.PointsSpent, totals.PointsSpent)
+
+	if len(differences) > 0 {
+		return fmt.Errorf("imported totals do not match the manifest: %s", strings.Join(differences, "; "))
+	}
+	return nil
+}
+
+func FileName(table string) string {
+	return table + ".jsonl"
+}
+
+// TableWriter encodes one JSON document per line while counting rows and
+// hashing the bytes written.
+type TableWriter struct {
+	writer  *bufio.Writer
+	hash    hash.Hash
+	encoder *json.Encoder
+	rows    int64
+}
+
+func NewTableWriter(w io.Writer) *TableWriter {
+	h := sha256.New()
+	buffered := bufio.NewWriter(io.MultiWriter(w, h))
+	return &TableWriter{
+		writer:  buffered,
+		hash:    h,
+		encoder: json.NewEncoder(buffered),
+	}
+}
+
+func (t *TableWriter) Write(row any) error {
+	if err := t.encoder.Encode(row); err != nil {
+		return err
+	}
+	t.rows++
+	return nil
+}
+
+// Close flushes buffered output and returns the row count and checksum.
+func (t *TableWriter) Close() (int64, string, error) {
+	if err := t.writer.Flush(); err != nil {
+		return 0, "", err
+	}
+	return t.rows, hex.EncodeToString(t.hash.Sum(nil)), nil
+}
+
+// Writer builds a gzip compressed tar with the manifest first and then the
+// table files in manifest order.
+type Writer struct {
+	gzip *gzip.Writer
+	tar  *tar.Writer
+}
+
+func NewWriter(w io.Writer) *Writer {
+	gz := gzip.NewWriter(w)
+	return &Writer{gzip: gz, tar: tar.NewWriter(gz)}
+}
+
+func (w *Writer) WriteManifest(manifest *Manifest) error {
+	payload, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	return w.writeEntry(ManifestName, int64(len(payload)), bytes.NewReader(payload), manifest.CreatedAt)
+}
+
+// WriteFile copies the file at path into the archive as name.
+func (w *Writer) WriteFile(name string, path string, modTime time.Time) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	stat, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	return w.writeEntry(name, stat.Size(), file, modTime)
+}
+
+func (w *Writer) writeEntry(name string, size int64, r io.Reader, modTime time.Time) error {
+	header := &tar.Header{
+		Name:    name,
+		Mode:    0o644,
+		Size:    size,
+		ModTime: modTime,
+	}
+	if err := w.tar.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err := io.Copy(w.tar, r)
+	return err
+}
+
+func (w *Writer) Close() error {
+	if err := w.tar.Close(); err != nil {
+		return err
+	}
+	return w.gzip.Close()
+}
+
+// Reader reads an archive written by Writer front to back.
+type Reader struct {
+	gzip     *gzip.Reader
+	tar      *tar.Reader
+	Manifest Manifest
+	next     int
+}
+
+// NewReader opens the archive and reads its manifest, which must be the
+// first entry and use a supported format version.
+func NewReader(r io.Reader) (*Reader, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("open archive: %w", err)
+	}
+
+	reader := &Reader{gzip: gz, tar: tar.NewReader(gz)}
+	header, err := reader.tar.Next()
+	if err != nil {
+		return nil, fmt.Errorf("read manifest: %w", err)
+	}
+	if header.Name != ManifestName {
+		return nil, fmt.Errorf("archive starts with %q, expected %s", header.Name, ManifestName)
+	}
+	if err := json.NewDecoder(reader.tar).Decode(&reader.Manifest); err != nil {
+		return nil, fmt.Errorf("decode manifest: %w", err)
+	}
+	if reader.Manifest.FormatVersion != FormatVersion {
+		return nil, fmt.Errorf("unsupported archive format version %d", reader.Manifest.FormatVersion)
+	}
+
+	return reader, nil
+}
+
+// Next returns the next table in manifest order, or io.EOF after the last
+// one. The archive entry must match the manifest.
+func (r *Reader) Next() (*TableEntry, *TableReader, error) {
+	if r.next >= len(r.Manifest.Tables) {
+		return nil, nil, io.EOF
+	}
+	entry := &r.Manifest.Tables[r.next]
+	r.next++
+
+	header, err := r.tar.Next()
+	if errors.Is(err, io.EOF) {
+		return nil, nil, fmt.Errorf("archive ends before %s", entry.File)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if header.Name != entry.File {
+		return nil, nil, fmt.Errorf("archive has %q where the manifest expects %s", header.Name, entry.File)
+	}
+
+	h := sha256.New()
+	return entry, &TableReader{
+		entry:   entry,
+		hash:    h,
+		decoder: json.NewDecoder(io.TeeReader(r.tar, h)),
+	}, nil
+}
+
+func (r *Reader) Close() error {
+	return r.gzip.Close()
+}
+
+// TableReader decodes the rows of one table and verifies them against the
+// manifest once exhausted.
+type TableReader struct {
+	entry   *TableEntry
+	hash    hash.Hash
+	decoder *json.Decoder
+	rows    int64
+}
+
+// Decode reads the next row into v. At the end of the file it checks the row
+// count and checksum and returns io.EOF when both match.
+func (t *TableReader) Decode(v any) error {
+	// More reads up to the next value or the end of the entry, so at the end
+	// every byte has passed through the hash.
+	if !t.decoder.More() {
+		return t.verify()
+	}
+
+	if err := t.decoder.Decode(v); err != nil {
+		return fmt.Errorf("%s row %d: %w", t.entry.File, t.rows+1, err)
+	}
+	t.rows++
+	return nil
+}
+
+func (t *TableReader) verify() error {
+	if t.rows != t.entry.Rows {
+		return fmt.Errorf("%s has %d rows, manifest says %d", t.entry.File, t.rows, t.entry.Rows)
+	}
+	if sum := hex.EncodeToString(t.hash.Sum(nil)); sum != t.entry.SHA256 {
+		return fmt.Errorf("%s checksum %s does not match manifest %s", t.entry.File, sum, t.entry.SHA256)
+	}
+	return io.EOF
+}
@@ -10,4 +10,11 @@ type Cache interface {
 	Set(ctx context.Context, key, value string, ttl time.Duration) error
 	Del(ctx context.Context, key string) error
 	DelByPrefix(ctx context.Context, prefix string) error
+	// Incr atomically increments the integer at key and (re)sets its ttl.
+	Incr(ctx context.Context, key string, ttl time.Duration) (int64, error)
+	// MGet returns the values of keys in order, with "" for missing keys.
+	MGet(ctx context.Context, keys ...string) ([]string, error)
+	// TTL reports whether key exists and its remaining time to live, with 0
+	// meaning the key never expires.
+	TTL(ctx context.Context, key string) (time.Duration, bool, error)
 }
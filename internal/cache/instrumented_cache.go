@@ -0,0 +1,168 @@
+package cache
+
+import (
+	"context"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// NamespaceStats are the counters collected for one key namespace.
+type NamespaceStats struct {
+	Namespace  string
+	Hits       int64
+	Misses     int64
+	Errors     int64
+	Operations int64
+	// TotalLatency and MaxLatency cover every operation in the namespace.
+	TotalLatency time.Duration
+	MaxLatency   time.Duration
+}
+
+// InstrumentedCache wraps a Cache and records hit, miss, error and latency
+// figures per key namespace (see Namespace).
+type InstrumentedCache struct {
+	Cache
+
+	mu    sync.Mutex
+	stats map[string]*NamespaceStats
+}
+
+func NewInstrumentedCache(inner Cache) *InstrumentedCache {
+	return &InstrumentedCache{
+		Cache: inner,
+		stats: make(map[string]*NamespaceStats),
+	}
+}
+
+// Namespace groups keys by their first two colon separated segments, so
+// "products:date:2025-01-01" belongs to "products:date". Keys with fewer
+// segments are grouped by their first segment.
+func Namespace(key string) string {
+	parts := strings.SplitN(key, ":", 3)
+	if len(parts) < 3 {
+		return parts[0]
+	}
+	return parts[0] + ":" + parts[1]
+}
+
+func (c *InstrumentedCache) Get(ctx context.Context, key string) (string, bool, error) {
+	start := time.Now()
+	value, ok, err := c.Cache.Get(ctx, key)
+	c.record(Namespace(key), start, err, boolCount(ok), boolCount(!ok && err == nil))
+	return value, ok, err
+}
+
+func (c *InstrumentedCache) Set(ctx context.Context, key, value string, ttl time.Duration) error {
+	start := time.Now()
+	err := c.Cache.Set(ctx, key, value, ttl)
+	c.record(Namespace(key), start, err, 0, 0)
+	return err
+}
+
+func (c *InstrumentedCache) Del(ctx context.Context, key string) error {
+	start := time.Now()
+	err := c.Cache.Del(ctx, key)
+	c.record(Namespace(key), start, err, 0, 0)
+	return err
+}
+
+func (c *InstrumentedCache) DelByPrefix(ctx context.Context, prefix string) error {
+	start := time.Now()
+	err := c.Cache.DelByPrefix(ctx, prefix)
+	c.record(Namespace(prefix), start, err, 0, 0)
+	return err
+}
+
+func (c *InstrumentedCache) Incr(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	start := time.Now()
+	value, err := c.Cache.Incr(ctx, key, ttl)
+	c.record(Namespace(key), start, err, 0, 0)
+	return value, err
+}
+
+// MGet counts a hit or miss per key; the call's latency is attributed to
+// the namespace of the first key.
+func (c *InstrumentedCache) MGet(ctx context.Context, keys ...string) ([]string, error) {
+	start := time.Now()
+	values, err := c.Cache.MGet(ctx, keys...)
+	if len(keys) == 0 {
+		return values, err
+	}
+
+	var hits, misses int64
+	for _, value := range values {
+		if value == "" {
+			misses++
+		} else {
+			hits++
+		}
+	}
+	c.record(Namespace(keys[0]), start, err, hits, misses)
+	return values, err
+}
+
+func (c *InstrumentedCache) TTL(ctx context.Context, key string) (time.Duration, bool, error) {
+	start := time.Now()
+	ttl, ok, err := c.Cache.TTL(ctx, key)
+	c.record(Namespace(key), start, err, 0, 0)
+	return ttl, ok, err
+}
+
+// Close releases the wrapped cache when it holds resources of its own, such as
+// the invalidation subscription of a TieredCache.
+func (c *InstrumentedCache) Close() error {
+	if closer, ok := c.Cache.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// Stats returns a snapshot of every namespace seen so far, sorted by name.
+func (c *InstrumentedCache) Stats() []NamespaceStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := make([]NamespaceStats, 0, len(c.stats))
+	for _, namespaceStats := range c.stats {
+		stats = append(stats, *namespaceStats)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].Namespace < stats[j].Namespace
+	})
+
+	return stats
+}
+
+func (c *InstrumentedCache) record(namespace string, start time.Time, err error, hits, misses int64) {
+	latency := time.Since(start)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	namespaceStats, ok := c.stats[namespace]
+	if !ok {
+		namespaceStats = &NamespaceStats{Namespace: namespace}
+		c.stats[namespace] = namespaceStats
+	}
+
+	namespaceStats.Operations++
+	namespaceStats.Hits += hits
+	namespaceStats.Misses += misses
+	if err != nil {
+		namespaceStats.Errors++
+	}
+	namespaceStats.TotalLatency += latency
+	if latency > namespaceStats.MaxLatency {
+		namespaceStats.MaxLatency = latency
+	}
+}
+
+func boolCount(value bool) int64 {
+	if value {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,124 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/singleflight"
+)
+
+// RefreshTimeout bounds a background stale-while-revalidate reload.
+const RefreshTimeout = 30 * time.Second
+
+// LoadFunc computes the value to cache for a key.
+type LoadFunc func(ctx context.Context) (string, error)
+
+type loaderEntry struct {
+	Value      string    `json:"value"`
+	FreshUntil time.Time `json:"fresh_until"`
+}
+
+// Loader is a read-through wrapper around Cache. Concurrent misses on the
+// same key share a single load, and with StaleTTL set an expired value is
+// served for that long while one background load refreshes it.
+type Loader struct {
+	Cache    Cache
+	Log      *logrus.Logger
+	StaleTTL time.Duration
+
+	group singleflight.Group
+}
+
+func NewLoader(cache Cache, log *logrus.Logger, staleTTL time.Duration) *Loader {
+	return &Loader{
+		Cache:    cache,
+		Log:      log,
+		StaleTTL: staleTTL,
+	}
+}
+
+// Load returns the cached value for key, calling load on a miss. The value
+// is fresh for ttl. A shared load is detached from the caller's cancellation
+// so one client going away does not fail every request waiting on it.
+func (l *Loader) Load(ctx context.Context, key string, ttl time.Duration, load LoadFunc) (string, error) {
+	if entry, ok := l.get(ctx, key); ok {
+		if time.Now().Before(entry.FreshUntil) {
+			return entry.Value, nil
+		}
+		if l.StaleTTL > 0 {
+			l.refresh(ctx, key, ttl, load)
+			return entry.Value, nil
+		}
+	}
+
+	value, err, _ := l.group.Do(key, func() (any, error) {
+		return l.fill(context.WithoutCancel(ctx), key, ttl, load)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return value.(string), nil
+}
+
+func (l *Loader) get(ctx context.Context, key string) (*loaderEntry, bool) {
+	if l.Cache == nil {
+		return nil, false
+	}
+
+	cached, ok, err := l.Cache.Get(ctx, key)
+	if err != nil {
+		l.Log.Warnf("Failed to get cache %s : %+v", key, err)
+		return nil, false
+	}
+	if !ok {
+		return nil, false
+	}
+
+	// Values cached before the envelope existed decode without error into an
+	// empty entry; treat anything that is not an envelope as a miss.
+	var entry loaderEntry
+	if err := json.Unmarshal([]byte(cached), &entry); err != nil || entry.Value == "" || entry.FreshUntil.IsZero() {
+		l.Log.Warnf("Failed to decode cache %s", key)
+		return nil, false
+	}
+
+	return &entry, true
+}
+
+// refresh reloads key in the background unless a load for it is already in
+// flight; the result channel is buffered, so it can be dropped.
+func (l *Loader) refresh(ctx context.Context, key string, ttl time.Duration, load LoadFunc) {
+	l.group.DoChan(key, func() (any, error) {
+		refreshCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), RefreshTimeout)
+		defer cancel()
+
+		value, err := l.fill(refreshCtx, key, ttl, load)
+		if err != nil {
+			l.Log.Warnf("Failed to refresh cache %s : %+v", key, err)
+		}
+		return value, err
+	})
+}
+
+func (l *Loader) fill(ctx context.Context, key string, ttl time.Duration, load LoadFunc) (string, error) {
+	value, err := load(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if l.Cache == nil {
+		return value, nil
+	}
+
+	payload, err := json.Marshal(loaderEntry{Value: value, FreshUntil: time.Now().Add(ttl)})
+	if err != nil {
+		l.Log.Warnf("Failed to encode cache %s : %+v", key, err)
+	} else if err := l.Cache.Set(ctx, key, string(payload), ttl+l.StaleTTL); err != nil {
+		l.Log.Warnf("Failed to set cache %s : %+v", key, err)
+	}
+
+	return value, nil
+}
@@ -0,0 +1,188 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type memoryEntry struct {
+	key       string
+	value     string
+	expiresAt time.Time
+}
+
+func (e *memoryEntry) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && !now.Before(e.expiresAt)
+}
+
+// MemoryCache is an in-process Cache with per-key TTL and least recently used
+// eviction once MaxEntries is reached. It is safe for concurrent use but is
+// not shared between instances, so it suits local runs, tests and a fallback
+// when Redis is unavailable.
+type MemoryCache struct {
+	MaxEntries int
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List
+}
+
+func NewMemoryCache(maxEntries int) *MemoryCache {
+	return &MemoryCache{
+		MaxEntries: maxEntries,
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+	}
+}
+
+func (m *MemoryCache) Get(_ context.Context, key string) (string, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	element, ok := m.entries[key]
+	if !ok {
+		return "", false, nil
+	}
+
+	entry := element.Value.(*memoryEntry)
+	if entry.expired(time.Now()) {
+		m.remove(element)
+		return "", false, nil
+	}
+
+	m.order.MoveToFront(element)
+	return entry.value, true, nil
+}
+
+// Set stores value under key. A ttl of zero or less keeps the key until it is
+// deleted or evicted, matching Redis SET without expiry.
+func (m *MemoryCache) Set(_ context.Context, key, value string, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.set(key, value, ttl)
+	return nil
+}
+
+func (m *MemoryCache) set(key, value string, ttl time.Duration) {
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = time.Now().Add(ttl)
+	}
+
+	if element, ok := m.entries[key]; ok {
+		entry := element.Value.(*memoryEntry)
+		entry.value = value
+		entry.expiresAt = expiresAt
+		m.order.MoveToFront(element)
+		return
+	}
+
+	m.entries[key] = m.order.PushFront(&memoryEntry{key: key, value: value, expiresAt: expiresAt})
+	m.evict()
+}
+
+func (m *MemoryCache) Del(_ context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if element, ok := m.entries[key]; ok {
+		m.remove(element)
+	}
+	return nil
+}
+
+func (m *MemoryCache) DelByPrefix(_ context.Context, prefix string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for key, element := range m.entries {
+		if strings.HasPrefix(key, prefix) {
+			m.remove(element)
+		}
+	}
+	return nil
+}
+
+func (m *MemoryCache) Incr(_ context.Context, key string, ttl time.Duration) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var current int64
+	if element, ok := m.entries[key]; ok {
+		entry := element.Value.(*memoryEntry)
+		if !entry.expired(time.Now()) {
+			parsed, err := strconv.ParseInt(entry.value, 10, 64)
+			if err != nil {
+				return 0, fmt.Errorf("value at %s is not an integer: %w", key, err)
+			}
+			current = parsed
+		}
+	}
+
+	current++
+	m.set(key, strconv.FormatInt(current, 10), ttl)
+	return current, nil
+}
+
+func (m *MemoryCache) MGet(ctx context.Context, keys ...string) ([]string, error) {
+	values := make([]string, len(keys))
+	for i, key := range keys {
+		value, _, _ := m.Get(ctx, key)
+		values[i] = value
+	}
+	return values, nil
+}
+
+func (m *MemoryCache) TTL(_ context.Context, key string) (time.Duration, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	element, ok := m.entries[key]
+	if !ok {
+		return 0, false, nil
+	}
+
+	entry := element.Value.(*memoryEntry)
+	now := time.Now()
+	if entry.expired(now) {
+		m.remove(element)
+		return 0, false, nil
+	}
+	if entry.expiresAt.IsZero() {
+		return 0, true, nil
+	}
+
+	return entry.expiresAt.Sub(now), true, nil
+}
+
+// Len returns the number of stored keys, including expired ones that have not
+// been touched since they expired.
+func (m *MemoryCache) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.order.Len()
+}
+
+// evict drops least recently used keys until the cache fits MaxEntries.
+// Expired keys are removed lazily on Get, or here once they go cold.
+func (m *MemoryCache) evict() {
+	if m.MaxEntries <= 0 {
+		return
+	}
+
+	for m.order.Len() > m.MaxEntries {
+		m.remove(m.order.Back())
+	}
+}
+
+func (m *MemoryCache) remove(element *list.Element) {
+	m.order.Remove(element)
+	delete(m.entries, element.Value.(*memoryEntry).key)
+}
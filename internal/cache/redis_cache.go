@@ -62,3 +62,57 @@ func (r *RedisCache) DelByPrefix(ctx context.Context, prefix string) error {
 
 	return nil
 }
+
+func (r *RedisCache) Incr(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	var incr *redis.IntCmd
+	_, err := r.Client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		incr = pipe.Incr(ctx, key)
+		if ttl > 0 {
+			pipe.Expire(ctx, key, ttl)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return incr.Val(), nil
+}
+
+func (r *RedisCache) MGet(ctx context.Context, keys ...string) ([]string, error) {
+	values := make([]string, len(keys))
+	if len(keys) == 0 {
+		return values, nil
+	}
+
+	results, err := r.Client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	for i, result := range results {
+		if value, ok := result.(string); ok {
+			values[i] = value
+		}
+	}
+
+	return values, nil
+}
+
+func (r *RedisCache) TTL(ctx context.Context, key string) (time.Duration, bool, error) {
+	ttl, err := r.Client.PTTL(ctx, key).Result()
+	if err != nil {
+		return 0, false, err
+	}
+
+	// go-redis passes PTTL's -2 (missing key) and -1 (no expiry) through
+	// unscaled.
+	switch ttl {
+	case -2:
+		return 0, false, nil
+	case -1:
+		return 0, true, nil
+	}
+
+	return ttl, true, nil
+}
@@ -0,0 +1,243 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	invalidateKey    = "key"
+	invalidatePrefix = "prefix"
+)
+
+type invalidationMessage struct {
+	Origin string `json:"origin"`
+	Kind   string `json:"kind"`
+	Key    string `json:"key"`
+}
+
+// TieredCache keeps a short-lived in-process L1 in front of a shared L2. Every
+// write or delete is applied to both tiers; deletes and increments are also
+// broadcast on a Redis pub/sub channel so other replicas drop the key from
+// their L1. Set is not broadcast, since most writes are loads of keys no other
+// replica holds; one that does keeps its copy for at most L1TTL, which also
+// bounds how stale a replica can be if a message is lost.
+type TieredCache struct {
+	L1      *MemoryCache
+	L2      Cache
+	L1TTL   time.Duration
+	Client  *redis.Client
+	Channel string
+	Log     *logrus.Logger
+
+	origin string
+	pubsub *redis.PubSub
+}
+
+// NewTieredCache wires the tiers and, when client is not nil, listens for
+// invalidations from other replicas until Close.
+func NewTieredCache(
+	l1 *MemoryCache,
+	l2 Cache,
+	l1TTL time.Duration,
+	client *redis.Client,
+	channel string,
+	log *logrus.Logger,
+) *TieredCache {
+	tiered := &TieredCache{
+		L1:      l1,
+		L2:      l2,
+		L1TTL:   l1TTL,
+		Client:  client,
+		Channel: channel,
+		Log:     log,
+		origin:  uuid.NewString(),
+	}
+
+	if client != nil {
+		tiered.pubsub = client.Subscribe(context.Background(), channel)
+		go tiered.listen(tiered.pubsub)
+	}
+
+	return tiered
+}
+
+func (t *TieredCache) Get(ctx context.Context, key string) (string, bool, error) {
+	if value, ok, _ := t.L1.Get(ctx, key); ok {
+		return value, true, nil
+	}
+
+	value, ok, err := t.L2.Get(ctx, key)
+	if err != nil || !ok {
+		return value, ok, err
+	}
+
+	_ = t.L1.Set(ctx, key, value, t.L1TTL)
+	return value, true, nil
+}
+
+func (t *TieredCache) Set(ctx context.Context, key, value string, ttl time.Duration) error {
+	if err := t.L2.Set(ctx, key, value, ttl); err != nil {
+		return err
+	}
+
+	_ = t.L1.Set(ctx, key, value, t.l1TTL(ttl))
+	return nil
+}
+
+func (t *TieredCache) Del(ctx context.Context, key string) error {
+	_ = t.L1.Del(ctx, key)
+	if err := t.L2.Del(ctx, key); err != nil {
+		return err
+	}
+
+	t.publish(ctx, invalidateKey, key)
+	return nil
+}
+
+func (t *TieredCache) DelByPrefix(ctx context.Context, prefix string) error {
+	_ = t.L1.DelByPrefix(ctx, prefix)
+	if err := t.L2.DelByPrefix(ctx, prefix); err != nil {
+		return err
+	}
+
+	t.publish(ctx, invalidatePrefix, prefix)
+	return nil
+}
+
+// Incr always goes to L2, which owns the counter, and drops the stale copy
+// from every L1.
+func (t *TieredCache) Incr(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	value, err := t.L2.Incr(ctx, key, ttl)
+	if err != nil {
+		return 0, err
+	}
+
+	_ = t.L1.Del(ctx, key)
+	t.publish(ctx, invalidateKey, key)
+	return value, nil
+}
+
+// MGet serves what it can from L1 and fetches the rest from L2 in one call.
+// Missing keys are not cached in L1.
+func (t *TieredCache) MGet(ctx context.Context, keys ...string) ([]string, error) {
+	values := make([]string, len(keys))
+	var missing []int
+	for i, key := range keys {
+		value, ok, _ := t.L1.Get(ctx, key)
+		if ok {
+			values[i] = value
+			continue
+		}
+		missing = append(missing, i)
+	}
+
+	if len(missing) == 0 {
+		return values, nil
+	}
+
+	missingKeys := make([]string, len(missing))
+	for i, index := range missing {
+		missingKeys[i] = keys[index]
+	}
+
+	fetched, err := t.L2.MGet(ctx, missingKeys...)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, index := range missing {
+		values[index] = fetched[i]
+		if fetched[i] != "" {
+			_ = t.L1.Set(ctx, keys[index], fetched[i], t.L1TTL)
+		}
+	}
+
+	return values, nil
+}
+
+// TTL reports the authoritative TTL from L2.
+func (t *TieredCache) TTL(ctx context.Context, key string) (time.Duration, bool, error) {
+	return t.L2.TTL(ctx, key)
+}
+
+// l1TTL caps ttl at L1TTL; a ttl of zero (no expiry) also gets L1TTL.
+func (t *TieredCache) l1TTL(ttl time.Duration) time.Duration {
+	if ttl <= 0 || ttl > t.L1TTL {
+		return t.L1TTL
+	}
+	return ttl
+}
+
+// Close stops listening for invalidations. The tiers themselves stay usable.
+func (t *TieredCache) Close() error {
+	if t.pubsub == nil {
+		return nil
+	}
+	return t.pubsub.Close()
+}
+
+func (t *TieredCache) publish(ctx context.Context, kind, key string) {
+	if t.Client == nil {
+		return
+	}
+
+	payload, err := json.Marshal(invalidationMessage{Origin: t.origin, Kind: kind, Key: key})
+	if err != nil {
+		t.Log.Warnf("Failed to encode cache invalidation : %+v", err)
+		return
+	}
+
+	if err := t.Client.Publish(ctx, t.Channel, payload).Err(); err != nil {
+		t.Log.Warnf("Failed to publish cache invalidation : %+v", err)
+	}
+}
+
+// listen applies invalidations published by other replicas. After a
+// reconnect messages may have been missed, so the whole L1 is dropped.
+func (t *TieredCache) listen(pubsub *redis.PubSub) {
+	ctx := context.Background()
+	subscribed := false
+
+	for message := range pubsub.ChannelWithSubscriptions() {
+		switch message := message.(type) {
+		case *redis.Subscription:
+			if message.Kind != "subscribe" {
+				continue
+			}
+			if subscribed {
+				t.Log.Warnf("Cache invalidation channel resubscribed, clearing local cache")
+				_ = t.L1.DelByPrefix(ctx, "")
+			}
+			subscribed = true
+		case *redis.Message:
+			t.Apply(ctx, message.Payload)
+		}
+	}
+}
+
+// Apply drops the keys named by an invalidation payload from L1, ignoring
+// the ones this instance published itself.
+func (t *TieredCache) Apply(ctx context.Context, payload string) {
+	var message invalidationMessage
+	if err := json.Unmarshal([]byte(payload), &message); err != nil {
+		t.Log.Warnf("Failed to decode cache invalidation : %+v", err)
+		return
+	}
+
+	if message.Origin == t.origin {
+		return
+	}
+
+	switch message.Kind {
+	case invalidateKey:
+		_ = t.L1.Del(ctx, message.Key)
+	case invalidatePrefix:
+		_ = t.L1.DelByPrefix(ctx, message.Key)
+	}
+}
@@ -0,0 +1,46 @@
+package cache
+
+import (
+	"context"
+	"hash/fnv"
+	"strconv"
+
+	"github.com/google/uuid"
+)
+
+// Version derives a cache key version from the stamps stored under keys.
+// Missing stamps, whether never written, retired or evicted, are replaced with
+// a fresh random one, so the result only ever moves to a value it has not had
+// before: a previously issued version cannot come back and serve data cached
+// under it.
+func Version(ctx context.Context, store Cache, keys ...string) (string, error) {
+	stamps, err := store.MGet(ctx, keys...)
+	if err != nil {
+		return "", err
+	}
+
+	hash := fnv.New64a()
+	for i, stamp := range stamps {
+		if stamp == "" {
+			stamp = uuid.NewString()
+			if err := store.Set(ctx, keys[i], stamp, 0); err != nil {
+				return "", err
+			}
+		}
+		hash.Write([]byte(stamp))
+		hash.Write([]byte{0})
+	}
+
+	return strconv.FormatUint(hash.Sum64(), 36), nil
+}
+
+// RetireVersion drops the stamps under keys so every version derived from them
+// changes on the next Version call.
+func RetireVersion(ctx context.Context, store Cache, keys ...string) error {
+	for _, key := range keys {
+		if err := store.Del(ctx, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,367 @@
+package command
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"snack-store-api/internal/archive"
+	"snack-store-api/internal/config"
+	"snack-store-api/internal/constants"
+	"snack-store-api/internal/entity"
+	"snack-store-api/internal/migrations"
+	"snack-store-api/internal/repository"
+
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+)
+
+func (ce *CommandExecutor) exportCommand() *cobra.Command {
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Dump the store's data to a portable archive",
+		Long: `Dump customers, products, shifts, transactions, redemptions and receipt
+sequences to a .tar.gz archive holding one JSONL file per table and a
+manifest with the schema version, row counts, SHA-256 checksums and point and
+stock totals.
+
+All tables are read from one repeatable-read snapshot, so the archive is
+consistent while the server keeps running. daily_sales_summary is not
+exported; import rebuilds it.`,
+		Example: `  snack-store-api export --output backup.tar.gz
+  snack-store-api export --output - > backup.tar.gz`,
+		Args: cobra.NoArgs,
+		RunE: ce.run(func(cmd *cobra.Command, _ []string) error {
+			if output == "" {
+				output = fmt.Sprintf("snack-store-%s.tar.gz", time.Now().Format("20060102-150405"))
+			}
+			return ce.export(cmd, output)
+		}),
+	}
+	cmd.Flags().StringVarP(&output, "output", "o", "", `archive path, "-" for stdout (default "snack-store-<timestamp>.tar.gz")`)
+
+	return cmd
+}
+
+func (ce *CommandExecutor) importCommand() *cobra.Command {
+	var batchSize int
+
+	cmd := &cobra.Command{
+		Use:   "import ARCHIVE",
+		Short: "Restore an archive written by export",
+		Long: `Restore an archive written by export into empty tables, keeping every id.
+
+The database must be migrated to the archive's schema version. Tables are
+restored in foreign key order inside one transaction with batched inserts;
+row counts, checksums and the point and stock totals are verified before
+commit, daily_sales_summary is rebuilt and the cached reports in Redis are
+retired. Customers whose points disagree with their transactions and
+redemptions are reported as warnings.`,
+		Example: `  snack-store-api migrate up && snack-store-api import backup.tar.gz
+  snack-store-api import - < backup.tar.gz`,
+		Args: cobra.ExactArgs(1),
+		RunE: ce.run(func(cmd *cobra.Command, args []string) error {
+			if batchSize <= 0 || batchSize > constants.MaxGenerateBatchSize {
+				return usageFailure(fmt.Errorf("--batch-size must be between 1 and %d", constants.MaxGenerateBatchSize))
+			}
+			return ce.importArchive(cmd, args[0], batchSize)
+		}),
+	}
+	cmd.Flags().IntVar(&batchSize, "batch-size", constants.DefaultGenerateBatchSize, "rows per insert statement")
+
+	return cmd
+}
+
+func (ce *CommandExecutor) export(cmd *cobra.Command, output string) error {
+	db, err := ce.database()
+	if err != nil {
+		return err
+	}
+
+	migrator, err := migrations.NewMigrator(db, ce.Log)
+	if err != nil {
+		return fmt.Errorf("failed to load migrations: %w", err)
+	}
+	schemaVersion, err := migrator.Version()
+	if err != nil {
+		return fmt.Errorf("failed to read schema version: %w", err)
+	}
+
+	dir, err := os.MkdirTemp("", "snack-store-export-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	manifest := &archive.Manifest{
+		FormatVersion: archive.FormatVersion,
+		SchemaVersion: schemaVersion,
+		CreatedAt:     time.Now().UTC(),
+	}
+	totals := &manifest.Totals
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		for _, table := range archive.Tables {
+			var entry archive.TableEntry
+			var err error
+			switch table {
+			case "customers":
+				entry, err = exportTable(tx, dir, table, "created_at, id", func(row *entity.Customer) {
+					totals.CustomerPoints += int64(row.Points)
+				})
+			case "products":
+				entry, err = exportTable(tx, dir, table, "created_at, id", func(row *entity.Product) {
+					totals.ProductStock += int64(row.StockQty)
+				})
+			case "shifts":
+				entry, err = exportTable[entity.Shift](tx, dir, table, "opened_at, id", nil)
+			case "transactions":
+				entry, err = exportTable(tx, dir, table, "transaction_at, id", func(row *entity.Transaction) {
+					totals.PointsEarned += int64(row.PointsEarned)
+				})
+			case "redemptions":
+				entry, err = exportTable(tx, dir, table, "redeem_at, id", func(row *entity.Redemption) {
+					totals.PointsSpent += int64(row.PointsSpent)
+				})
+			case "receipt_sequences":
+				entry, err = exportTable[entity.ReceiptSequence](tx, dir, table, "receipt_date", nil)
+			}
+			if err != nil {
+				return fmt.Errorf("export %s: %w", table, err)
+			}
+
+			ce.Log.Infof("Exported %s: %d rows", table, entry.Rows)
+			manifest.Tables = append(manifest.Tables, entry)
+		}
+		return nil
+	}, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return err
+	}
+
+	if output == "-" {
+		return writeArchive(cmd.OutOrStdout(), dir, manifest)
+	}
+
+	// Write next to the target and rename so a failed export never leaves a
+	// truncated archive under the final name.
+	partial := output + ".partial"
+	file, err := os.Create(partial)
+	if err != nil {
+		return err
+	}
+	if err := writeArchive(file, dir, manifest); err != nil {
+		file.Close()
+		os.Remove(partial)
+		return err
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(partial)
+		return err
+	}
+	if err := os.Rename(partial, output); err != nil {
+		return err
+	}
+
+	ce.Log.Infof("Export written to %s (schema version %d)", output, schemaVersion)
+	return nil
+}
+
+func exportTable[T any](db *gorm.DB, dir string, table string, order string, visit func(row *T)) (archive.TableEntry, error) {
+	entry := archive.TableEntry{Name: table, File: archive.FileName(table)}
+
+	file, err := os.Create(filepath.Join(dir, entry.File))
+	if err != nil {
+		return entry, err
+	}
+	defer file.Close()
+
+	writer := archive.NewTableWriter(file)
+	err = (&repository.Repository[T]{}).Each(db, order, func(row *T) error {
+		if visit != nil {
+			visit(row)
+		}
+		return writer.Write(row)
+	})
+	if err != nil {
+		return entry, err
+	}
+
+	entry.Rows, entry.SHA256, err = writer.Close()
+	return entry, err
+}
+
+func writeArchive(w io.Writer, dir string, manifest *archive.Manifest) error {
+	writer := archive.NewWriter(w)
+	if err := writer.WriteManifest(manifest); err != nil {
+		return err
+	}
+	for _, entry := range manifest.Tables {
+		if err := writer.WriteFile(entry.File, filepath.Join(dir, entry.File), manifest.CreatedAt); err != nil {
+			return err
+		}
+	}
+	return writer.Close()
+}
+
+func (ce *CommandExecutor) importArchive(cmd *cobra.Command, input string, batchSize int) error {
+	var source io.Reader = cmd.InOrStdin()
+	if input != "-" {
+		file, err := os.Open(input)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		source = file
+	}
+
+	reader, err := archive.NewReader(source)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	manifest := reader.Manifest
+
+	if err := manifest.CheckTables(); err != nil {
+		return err
+	}
+
+	db, err := ce.database()
+	if err != nil {
+		return err
+	}
+	migrator, err := migrations.NewMigrator(db, ce.Log)
+	if err != nil {
+		return fmt.Errorf("failed to load migrations: %w", err)
+	}
+	schemaVersion, err := migrator.Version()
+	if err != nil {
+		return fmt.Errorf("failed to read schema version: %w", err)
+	}
+	if schemaVersion != manifest.SchemaVersion {
+		return fmt.Errorf("archive has schema version %d but the database is at %d; migrate the database to the same version first", manifest.SchemaVersion, schemaVersion)
+	}
+
+	location := config.NewStoreLocation(ce.Viper, ce.Log)
+	summaryRepository := repository.NewDailySalesSummaryRepository(ce.Log, location)
+	customerRepository := repository.NewCustomerRepository(ce.Log, location)
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		for _, table := range archive.Tables {
+			var count int64
+			if err := tx.Table(table).Count(&count).Error; err != nil {
+				return err
+			}
+			if count > 0 {
+				return fmt.Errorf("table %s is not empty; import needs an empty database", table)
+			}
+		}
+
+		for {
+			entry, tableReader, err := reader.Next()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return err
+			}
+
+			var rows int64
+			switch entry.Name {
+			case "customers":
+				rows, err = importTable[entity.Customer](tx, tableReader, batchSize)
+			case "products":
+				rows, err = importTable[entity.Product](tx, tableReader, batchSize)
+			case "shifts":
+				rows, err = importTable[entity.Shift](tx, tableReader, batchSize)
+			case "transactions":
+				rows, err = importTable[entity.Transaction](tx, tableReader, batchSize)
+			case "redemptions":
+				rows, err = importTable[entity.Redemption](tx, tableReader, batchSize)
+			case "receipt_sequences":
+				rows, err = importTable[entity.ReceiptSequence](tx, tableReader, batchSize)
+			}
+			if err != nil {
+				return fmt.Errorf("import %s: %w", entry.Name, err)
+			}
+			ce.Log.Infof("Imported %s: %d rows", entry.Name, rows)
+		}
+
+		var totals archive.Totals
+		err := tx.Raw(`
+SELECT (SELECT COALESCE(SUM(points), 0) FROM customers) AS customer_points,
+       (SELECT COALESCE(SUM(stock_qty), 0) FROM products) AS product_stock,
+       (SELECT COALESCE(SUM(points_earned), 0) FROM transactions) AS points_earned,
+       (SELECT COALESCE(SUM(points_spent), 0) FROM redemptions) AS points_spent
+`).Scan(&totals).Error
+		if err != nil {
+			return fmt.Errorf("failed to compute totals: %w", err)
+		}
+		if err := manifest.CheckTotals(totals); err != nil {
+			return err
+		}
+
+		rebuilt, err := summaryRepository.Rebuild(tx)
+		if err != nil {
+			return fmt.Errorf("failed to rebuild daily sales summary: %w", err)
+		}
+		ce.Log.Infof("Daily sales summary rebuilt: %d rows", rebuilt)
+
+		mismatches, err := customerRepository.FindPointsMismatches(tx)
+		if err != nil {
+			return fmt.Errorf("failed to compare customer points: %w", err)
+		}
+		for _, row := range mismatches {
+			expected, status := entity.ReconcilePoints(row.Points, row.OpeningPoints, row.Earned, row.Spent)
+			if status == entity.PointsNoOpening {
+				ce.Log.Warnf("Customer %s %s has %d points and no opening balance", row.CustomerID, row.Name, row.Points)
+				continue
+			}
+			ce.Log.Warnf("Customer %s %s has %d points, ledger %d", row.CustomerID, row.Name, row.Points, expected)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("import failed: %w", err)
+	}
+	ce.retireReportCache()
+
+	ce.Log.Infof("Import of %s completed (schema version %d, exported %s)", input, manifest.SchemaVersion, manifest.CreatedAt.Format(time.RFC3339))
+	return nil
+}
+
+// importTable inserts every row of one table in batches and returns the row
+// count once the reader has verified it against the manifest.
+func importTable[T any](db *gorm.DB, reader *archive.TableReader, batchSize int) (int64, error) {
+	repo := &repository.Repository[T]{}
+	batch := make([]T, 0, batchSize)
+	var rows int64
+
+	for {
+		var row T
+		err := reader.Decode(&row)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return rows, err
+		}
+
+		batch = append(batch, row)
+		rows++
+		if len(batch) == batchSize {
+			if err := repo.CreateInBatches(db, batch, batchSize); err != nil {
+				return rows, err
+			}
+			batch = batch[:0]
+		}
+	}
+
+	return rows, repo.CreateInBatches(db, batch, batchSize)
+}
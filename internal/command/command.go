@@ -1,82 +1,202 @@
 package command
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
-	"snack-store-api/internal/migrations"
-	"strings"
+
+	"snack-store-api/internal/config"
+	"snack-store-api/internal/constants"
 
 	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"gorm.io/gorm"
 )
 
+// Exit codes returned by Execute.
+const (
+	ExitOK      = 0
+	ExitFailure = 1
+	ExitUsage   = 2
+	// ExitRefused means a destructive command was blocked by the environment
+	// guard or not confirmed.
+	ExitRefused = 3
+)
+
 type CommandExecutor struct {
-	DB    *gorm.DB
 	Viper *viper.Viper
+	Log   *logrus.Logger
+	// Connect opens the database the first time a command needs it.
+	Connect func() (*gorm.DB, error)
+	In      io.Reader
+	Out     io.Writer
+	Err     io.Writer
+	// IsTerminal reports whether In is interactive, which the confirmation
+	// prompt of destructive commands requires.
+	IsTerminal func() bool
+
+	db *gorm.DB
 }
 
-func NewCommandExecutor(viper *viper.Viper, db *gorm.DB) *CommandExecutor {
+func NewCommandExecutor(viper *viper.Viper, log *logrus.Logger) *CommandExecutor {
 	return &CommandExecutor{
-		DB:    db,
 		Viper: viper,
+		Log:   log,
+		Connect: func() (*gorm.DB, error) {
+			return config.NewDatabase(viper, log)
+		},
+		In:  os.Stdin,
+		Out: os.Stdout,
+		Err: os.Stderr,
+		IsTerminal: func() bool {
+			stat, err := os.Stdin.Stat()
+			return err == nil && stat.Mode()&os.ModeCharDevice != 0
+		},
 	}
 }
 
-func (ce *CommandExecutor) Execute(logger *logrus.Logger) bool {
-	args := os.Args[1:]
-	if len(args) == 0 {
-		return true
+// failure marks errors raised while a command ran, as opposed to flag and
+// argument errors reported by cobra before it.
+type failure struct {
+	code int
+	err  error
+}
+
+func (f *failure) Error() string {
+	return f.err.Error()
+}
+
+func (f *failure) Unwrap() error {
+	return f.err
+}
+
+// Execute runs the command named by args and returns the process exit code.
+// Running without a command starts the HTTP server.
+func (ce *CommandExecutor) Execute(args []string) int {
+	root := ce.rootCommand()
+	root.SetArgs(args)
+	root.SetIn(ce.In)
+	root.SetOut(ce.Out)
+	root.SetErr(ce.Err)
+
+	err := root.Execute()
+	if err == nil {
+		return ExitOK
 	}
 
-	run := false
-	for _, arg := range args {
-		switch arg {
-		case "--drop-table":
-			ce.handleDropTable(logger)
-		case "--migrate":
-			ce.handleMigrate(logger)
-		case "--seed":
-			ce.handleSeed(logger)
-		case "--run":
-			run = true
-		}
+	var f *failure
+	if errors.As(err, &f) {
+		ce.Log.Error(f.err)
+		return f.code
 	}
 
-	return run
+	fmt.Fprintf(root.ErrOrStderr(), "Error: %v\nRun '%s --help' for usage.\n", err, root.Name())
+	return ExitUsage
 }
 
-func (ce *CommandExecutor) handleMigrate(logger *logrus.Logger) {
-	if err := migrations.Migrate(ce.DB); err != nil {
-		logger.Fatalf("Migration failed: %v", err)
+func (ce *CommandExecutor) rootCommand() *cobra.Command {
+	root := &cobra.Command{
+		Use:   "snack-store-api",
+		Short: "Snack store API server and maintenance commands",
+		Long: `Snack store API server and maintenance commands.
+
+Running without a command is the same as "serve".
+
+Exit codes:
+  0  success
+  1  the command failed
+  2  invalid command, flag or argument
+  3  a destructive command was refused by the environment guard or not confirmed`,
+		Args:          cobra.NoArgs,
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		RunE: ce.run(func(cmd *cobra.Command, _ []string) error {
+			return ce.serve(false)
+		}),
 	}
-	logger.Println("Migration completed")
+	root.CompletionOptions.DisableDefaultCmd = true
+
+	root.AddCommand(
+		ce.serveCommand(),
+		ce.migrateCommand(),
+		ce.seedCommand(),
+		ce.generateCommand(),
+		ce.exportCommand(),
+		ce.importCommand(),
+		ce.dbCommand(),
+		ce.summariesCommand(),
+		ce.taxCommand(),
+		ce.pointsCommand(),
+	)
+
+	return root
 }
 
-func (ce *CommandExecutor) handleSeed(logger *logrus.Logger) {
-	if err := migrations.Seeder(ce.DB, logger); err != nil {
-		logger.Fatalf("Seeder failed: %v", err)
-	}
-	logger.Println("Seeder completed")
+// usageFailure reports an invalid flag value found while a command ran.
+func usageFailure(err error) error {
+	return &failure{code: ExitUsage, err: err}
 }
 
-func (ce *CommandExecutor) handleDropTable(logger *logrus.Logger) {
-	tables := ce.Viper.GetString("DROP_TABLE_NAMES")
-	if tables == "" {
-		logger.Fatal("DROP_TABLE_NAMES is not set in env")
+// groupCommand builds a parent command that only holds subcommands; running
+// it bare or with an unknown subcommand is a usage error.
+func groupCommand(use string, short string) *cobra.Command {
+	return &cobra.Command{
+		Use:   use,
+		Short: short,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return fmt.Errorf("%s requires a subcommand", cmd.CommandPath())
+		},
 	}
+}
 
-	tableList := strings.Split(tables, ",")
-	for _, table := range tableList {
-		table = strings.TrimSpace(table)
-		if table == "" {
-			continue
+// run wraps a command body so its errors are reported as failures rather
+// than usage errors.
+func (ce *CommandExecutor) run(fn func(cmd *cobra.Command, args []string) error) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		if err := fn(cmd, args); err != nil {
+			var f *failure
+			if errors.As(err, &f) {
+				return f
+			}
+			return &failure{code: ExitFailure, err: err}
 		}
+		return nil
+	}
+}
 
-		sql := fmt.Sprintf(`DROP TABLE IF EXISTS "%s" CASCADE`, table)
-		if err := ce.DB.Exec(sql).Error; err != nil {
-			logger.Fatalf("Failed to drop table '%s': %v", table, err)
+// database connects on first use so commands that never touch PostgreSQL do
+// not need it to be reachable.
+func (ce *CommandExecutor) database() (*gorm.DB, error) {
+	if ce.db == nil {
+		db, err := ce.Connect()
+		if err != nil {
+			return nil, err
 		}
-		logger.Printf("Table '%s' dropped\n", table)
+		ce.db = db
+	}
+	return ce.db, nil
+}
+
+// retireReportCache drops the report version stamps in the shared cache after
+// a command wrote sales behind the API's back, so running servers stop
+// serving reports cached before it. Servers on the in-memory cache cannot be
+// reached and drop theirs when the report TTL runs out.
+func (ce *CommandExecutor) retireReportCache() {
+	redisClient := config.NewRedis(ce.Viper, ce.Log)
+	if redisClient == nil {
+		return
+	}
+	defer redisClient.Close()
+
+	store := config.NewCache(ce.Viper, ce.Log, redisClient)
+	defer store.Close()
+	if err := store.DelByPrefix(context.Background(), constants.ReportVersionKeyPrefix); err != nil {
+		ce.Log.Warnf("Failed to retire cached reports : %+v", err)
+		return
 	}
+	ce.Log.Info("Cached reports retired")
 }
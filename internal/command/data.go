@@ -0,0 +1,314 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"snack-store-api/internal/config"
+	"snack-store-api/internal/entity"
+	"snack-store-api/internal/migrations"
+	"snack-store-api/internal/repository"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+)
+
+func (ce *CommandExecutor) seedCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "seed",
+		Short: "Load the JSON seed data into empty tables",
+		Args:  cobra.NoArgs,
+		RunE: ce.run(func(cmd *cobra.Command, _ []string) error {
+			db, err := ce.database()
+			if err != nil {
+				return err
+			}
+			if err := migrations.Seeder(db, ce.Log); err != nil {
+				return fmt.Errorf("seeder failed: %w", err)
+			}
+			ce.Log.Info("Seeder completed")
+
+			summaryRepository := repository.NewDailySalesSummaryRepository(ce.Log, config.NewStoreLocation(ce.Viper, ce.Log))
+			var rebuilt int64
+			err = db.Transaction(func(tx *gorm.DB) error {
+				var err error
+				rebuilt, err = summaryRepository.Rebuild(tx)
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("failed to rebuild daily sales summary: %w", err)
+			}
+			ce.Log.Infof("Daily sales summary rebuilt: %d rows", rebuilt)
+
+			ce.retireReportCache()
+			return nil
+		}),
+	}
+}
+
+func (ce *CommandExecutor) dbCommand() *cobra.Command {
+	cmd := groupCommand("db", "Database maintenance")
+
+	drop := &cobra.Command{
+		Use:   "drop",
+		Short: "Drop the tables listed in DROP_TABLE_NAMES",
+		Long: `Drop the tables listed in DROP_TABLE_NAMES with CASCADE.
+
+Refused when APP_ENV=production unless --allow-production is given, and
+asks for the database name unless --yes is given.`,
+		Example: "  snack-store-api db drop --yes",
+		Args:    cobra.NoArgs,
+		RunE: ce.run(func(cmd *cobra.Command, _ []string) error {
+			var tables []string
+			for _, table := range strings.Split(ce.Viper.GetString("DROP_TABLE_NAMES"), ",") {
+				if table = strings.TrimSpace(table); table != "" {
+					tables = append(tables, table)
+				}
+			}
+			if len(tables) == 0 {
+				return fmt.Errorf("DROP_TABLE_NAMES is not set in env")
+			}
+
+			if err := ce.confirm(cmd, "drop tables "+strings.Join(tables, ", ")); err != nil {
+				return err
+			}
+
+			db, err := ce.database()
+			if err != nil {
+				return err
+			}
+			for _, table := range tables {
+				sql := fmt.Sprintf(`DROP TABLE IF EXISTS "%s" CASCADE`, table)
+				if err := db.Exec(sql).Error; err != nil {
+					return fmt.Errorf("failed to drop table '%s': %w", table, err)
+				}
+				ce.Log.Infof("Table '%s' dropped", table)
+			}
+			ce.retireReportCache()
+			return nil
+		}),
+	}
+	destructiveFlags(drop)
+
+	cmd.AddCommand(drop)
+	return cmd
+}
+
+func (ce *CommandExecutor) summariesCommand() *cobra.Command {
+	cmd := groupCommand("summaries", "Maintain the daily sales summary")
+
+	rebuild := &cobra.Command{
+		Use:   "rebuild",
+		Short: "Rebuild daily_sales_summary from transactions and verify it",
+		Long: `Rebuild daily_sales_summary from transactions and verify the result,
+failing when any day/product pair still disagrees. Run it after seeding or
+after editing transactions directly in the database.`,
+		Args: cobra.NoArgs,
+		RunE: ce.run(func(cmd *cobra.Command, _ []string) error {
+			return ce.rebuildSummaries()
+		}),
+	}
+
+	cmd.AddCommand(rebuild)
+	return cmd
+}
+
+func (ce *CommandExecutor) rebuildSummaries() error {
+	db, err := ce.database()
+	if err != nil {
+		return err
+	}
+	summaryRepository := repository.NewDailySalesSummaryRepository(ce.Log, config.NewStoreLocation(ce.Viper, ce.Log))
+
+	var rebuilt int64
+	err = db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		rebuilt, err = summaryRepository.Rebuild(tx)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to rebuild daily sales summary: %w", err)
+	}
+	ce.Log.Infof("Daily sales summary rebuilt: %d rows", rebuilt)
+	ce.retireReportCache()
+
+	mismatches, err := summaryRepository.FindMismatches(db)
+	if err != nil {
+		return fmt.Errorf("failed to verify daily sales summary: %w", err)
+	}
+	for _, row := range mismatches {
+		ce.Log.Warnf(
+			"Summary mismatch %s product %s: qty %d/%d, revenue %d/%d, transactions %d/%d",
+			row.SalesDate.Format("2006-01-02"),
+			row.ProductID,
+			row.SummaryQty, row.ActualQty,
+			row.SummaryRevenue, row.ActualRevenue,
+			row.SummaryTransactions, row.ActualTransactions,
+		)
+	}
+	if len(mismatches) > 0 {
+		return fmt.Errorf("daily sales summary verification failed: %d mismatches", len(mismatches))
+	}
+	ce.Log.Info("Daily sales summary verified")
+	return nil
+}
+
+func (ce *CommandExecutor) taxCommand() *cobra.Command {
+	cmd := groupCommand("tax", "Maintain transaction tax columns")
+
+	var dryRun bool
+	backfill := &cobra.Command{
+		Use:   "backfill",
+		Short: "Fill net_amount, tax_amount and tax_rate_bps on older transactions",
+		Long: `Fill net_amount, tax_amount and tax_rate_bps on transactions recorded before
+tax was tracked (net_amount = 0 with a positive total_price). The rate comes
+from TAX_RATES / TAX_RATE by product type, and total_price is treated as the
+tax-inclusive amount the customer paid, whatever TAX_PRICE_INCLUSIVE says.
+With --dry-run only the affected transactions per type are listed.`,
+		Example: `  snack-store-api tax backfill --dry-run
+  snack-store-api tax backfill --yes`,
+		Args: cobra.NoArgs,
+		RunE: ce.run(func(cmd *cobra.Command, _ []string) error {
+			if !dryRun {
+				if err := ce.guardEnvironment(cmd, "fill transaction tax"); err != nil {
+					return err
+				}
+			}
+
+			db, err := ce.database()
+			if err != nil {
+				return err
+			}
+			policy := config.NewTaxPolicy(ce.Viper, ce.Log)
+			transactionRepository := repository.NewTransactionRepository(ce.Log)
+
+			rows, err := transactionRepository.FindTaxBackfill(db)
+			if err != nil {
+				return fmt.Errorf("failed to find transactions without tax: %w", err)
+			}
+			var pending int64
+			for _, row := range rows {
+				pending += row.Transactions
+				fmt.Fprintf(cmd.OutOrStdout(), "%s: %d transactions, total %d, rate %d bps\n", row.ProductType, row.Transactions, row.TotalPrice, policy.RateFor(row.ProductType))
+			}
+			if pending == 0 {
+				ce.Log.Info("All transactions have tax filled")
+				return nil
+			}
+			if dryRun {
+				ce.Log.Infof("Transactions without tax: %d (dry run)", pending)
+				return nil
+			}
+
+			if err := ce.confirm(cmd, fmt.Sprintf("fill tax on %d transaction(s)", pending)); err != nil {
+				return err
+			}
+
+			var updated int64
+			err = db.Transaction(func(tx *gorm.DB) error {
+				for _, row := range rows {
+					affected, err := transactionRepository.BackfillTax(tx, row.ProductType, policy.RateFor(row.ProductType))
+					if err != nil {
+						return err
+					}
+					updated += affected
+				}
+				return nil
+			})
+			if err != nil {
+				return fmt.Errorf("failed to backfill tax: %w", err)
+			}
+			ce.Log.Infof("Transaction tax backfilled: %d updated", updated)
+			ce.retireReportCache()
+			return nil
+		}),
+	}
+	backfill.Flags().BoolVar(&dryRun, "dry-run", false, "only list transactions without tax")
+	destructiveFlags(backfill)
+
+	cmd.AddCommand(backfill)
+	return cmd
+}
+
+func (ce *CommandExecutor) pointsCommand() *cobra.Command {
+	cmd := groupCommand("points", "Maintain customer point balances")
+
+	var dryRun bool
+	recalc := &cobra.Command{
+		Use:   "recalc",
+		Short: "Recompute customer points from transactions and redemptions",
+		Long: `Recompute every customer's balance as the opening balance (opening_points)
+plus points earned on transactions minus points spent on redemptions, list
+the customers whose stored balance differs and overwrite them. With --dry-run
+only the list is printed.
+
+Customers without an opening balance, or whose ledger balance would be
+negative, are listed but never overwritten; set their opening_points after
+review. The command fails while any of them remain.`,
+		Example: `  snack-store-api points recalc --dry-run
+  snack-store-api points recalc --yes`,
+		Args: cobra.NoArgs,
+		RunE: ce.run(func(cmd *cobra.Command, _ []string) error {
+			if !dryRun {
+				if err := ce.guardEnvironment(cmd, "overwrite customer points"); err != nil {
+					return err
+				}
+			}
+
+			db, err := ce.database()
+			if err != nil {
+				return err
+			}
+			customerRepository := repository.NewCustomerRepository(ce.Log, config.NewStoreLocation(ce.Viper, ce.Log))
+
+			mismatches, err := customerRepository.FindPointsMismatches(db)
+			if err != nil {
+				return fmt.Errorf("failed to compare customer points: %w", err)
+			}
+
+			var correctable []uuid.UUID
+			var unresolved int
+			for _, row := range mismatches {
+				expected, status := entity.ReconcilePoints(row.Points, row.OpeningPoints, row.Earned, row.Spent)
+				switch status {
+				case entity.PointsCorrectable:
+					correctable = append(correctable, row.CustomerID)
+					fmt.Fprintf(cmd.OutOrStdout(), "%s %s: stored %d, ledger %d\n", row.CustomerID, row.Name, row.Points, expected)
+				case entity.PointsNoOpening:
+					unresolved++
+					fmt.Fprintf(cmd.OutOrStdout(), "%s %s: stored %d, earned %d, spent %d, no opening balance (not changed)\n", row.CustomerID, row.Name, row.Points, row.Earned, row.Spent)
+				case entity.PointsNegative:
+					unresolved++
+					fmt.Fprintf(cmd.OutOrStdout(), "%s %s: stored %d, ledger %d is negative (not changed)\n", row.CustomerID, row.Name, row.Points, expected)
+				}
+			}
+
+			if len(correctable) == 0 {
+				ce.Log.Info("No customer points to overwrite")
+			} else if dryRun {
+				ce.Log.Infof("Customer points mismatched: %d customers (dry run)", len(correctable))
+			} else {
+				if err := ce.confirm(cmd, fmt.Sprintf("overwrite points for %d customer(s)", len(correctable))); err != nil {
+					return err
+				}
+
+				updated, err := customerRepository.RecalculatePoints(db, correctable)
+				if err != nil {
+					return fmt.Errorf("failed to recalculate customer points: %w", err)
+				}
+				ce.Log.Infof("Customer points recalculated: %d updated", updated)
+			}
+
+			if unresolved > 0 {
+				return fmt.Errorf("%d customer(s) need an opening balance or a manual review", unresolved)
+			}
+			return nil
+		}),
+	}
+	recalc.Flags().BoolVar(&dryRun, "dry-run", false, "only list mismatched balances")
+	destructiveFlags(recalc)
+
+	cmd.AddCommand(recalc)
+	return cmd
+}
@@ -0,0 +1,178 @@
+package command
+
+import (
+	"fmt"
+	"time"
+
+	"snack-store-api/internal/config"
+	"snack-store-api/internal/constants"
+	"snack-store-api/internal/entity"
+	"snack-store-api/internal/generator"
+	"snack-store-api/internal/repository"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+)
+
+func (ce *CommandExecutor) generateCommand() *cobra.Command {
+	var (
+		options generator.Options
+		from    string
+		to      string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "generate",
+		Short: "Fill empty tables with synthetic data for load and demo environments",
+		Long: `Generate customers, products across every flavor and size, transactions with
+weekly, payday and yearly seasonality, repeat customers and churn, and point
+redemptions. Points, stock, receipt numbers and the daily sales summary are
+consistent with the generated transactions. The same --seed with the same
+flags, --to included, always produces the same data; without --to the range
+ends today, so the output changes from day to day.
+
+The customers, products, transactions, redemptions and receipt_sequences
+tables must be empty;
+run "db drop" and "migrate up" first. Everything is written in one database
+transaction with batched inserts, after which the cached reports in Redis are
+retired.`,
+		Example: `  snack-store-api generate
+  snack-store-api generate --customers 20000 --transactions 1000000 --from 2024-01-01 --to 2025-12-31 --seed 42`,
+		Args: cobra.NoArgs,
+		RunE: ce.run(func(cmd *cobra.Command, _ []string) error {
+			location := config.NewStoreLocation(ce.Viper, ce.Log)
+
+			var err error
+			options.End = time.Now().In(location)
+			if to != "" {
+				if options.End, err = time.ParseInLocation(constants.DateLayout, to, location); err != nil {
+					return usageFailure(fmt.Errorf("invalid --to %q", to))
+				}
+			}
+			options.Start = options.End.AddDate(0, 0, -constants.DefaultGenerateDays+1)
+			if from != "" {
+				if options.Start, err = time.ParseInLocation(constants.DateLayout, from, location); err != nil {
+					return usageFailure(fmt.Errorf("invalid --from %q", from))
+				}
+			}
+			if options.BatchSize > constants.MaxGenerateBatchSize {
+				return usageFailure(fmt.Errorf("--batch-size must be at most %d", constants.MaxGenerateBatchSize))
+			}
+
+			if err := ce.guardEnvironment(cmd, "generate synthetic data"); err != nil {
+				return err
+			}
+
+			return ce.generate(options, location)
+		}),
+	}
+
+	flags := cmd.Flags()
+	flags.IntVar(&options.Customers, "customers", constants.DefaultGenerateCustomers, "number of customers")
+	flags.IntVar(&options.Products, "products", constants.DefaultGenerateProducts, "number of products; the first 21 cover every flavor and size")
+	flags.IntVar(&options.Transactions, "transactions", constants.DefaultGenerateTransactions, "number of transactions")
+	flags.StringVar(&from, "from", "", "first sales day, YYYY-MM-DD (default: a year before --to)")
+	flags.StringVar(&to, "to", "", "last sales day, YYYY-MM-DD (default: today)")
+	flags.Uint64Var(&options.Seed, "seed", 1, "random seed")
+	flags.IntVar(&options.BatchSize, "batch-size", constants.DefaultGenerateBatchSize, "rows per insert statement")
+	flags.Bool("allow-production", false, "allow running when APP_ENV=production")
+
+	return cmd
+}
+
+func (ce *CommandExecutor) generate(options generator.Options, location *time.Location) error {
+	taxPolicy := config.NewTaxPolicy(ce.Viper, ce.Log)
+	summaryRepository := repository.NewDailySalesSummaryRepository(ce.Log, location)
+	customerRepository := repository.NewCustomerRepository(ce.Log, location)
+
+	db, err := ce.database()
+	if err != nil {
+		return err
+	}
+
+	var summary *generator.Summary
+	err = db.Transaction(func(tx *gorm.DB) error {
+		for _, table := range []string{"customers", "products", "transactions", "redemptions", "receipt_sequences"} {
+			var count int64
+			if err := tx.Table(table).Count(&count).Error; err != nil {
+				return err
+			}
+			if count > 0 {
+				return fmt.Errorf("table %s is not empty; run db drop and migrate up first", table)
+			}
+		}
+
+		var err error
+		summary, err = generator.Generate(options, taxPolicy, location, newDatabaseSink(tx, ce.Log, options.BatchSize))
+		if err != nil {
+			return err
+		}
+
+		rebuilt, err := summaryRepository.Rebuild(tx)
+		if err != nil {
+			return fmt.Errorf("failed to rebuild daily sales summary: %w", err)
+		}
+		ce.Log.Infof("Daily sales summary rebuilt: %d rows", rebuilt)
+
+		mismatches, err := customerRepository.FindPointsMismatches(tx)
+		if err != nil {
+			return fmt.Errorf("failed to verify customer points: %w", err)
+		}
+		if len(mismatches) > 0 {
+			return fmt.Errorf("generated points disagree with the ledger for %d customers", len(mismatches))
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("generate failed: %w", err)
+	}
+	ce.retireReportCache()
+
+	openingStock := 0
+	for _, qty := range summary.OpeningStock {
+		openingStock += qty
+	}
+	ce.Log.Infof(
+		"Generated %d customers, %d products, %d transactions, %d redemptions, revenue %d, opening stock %d units",
+		summary.Customers, summary.Products, summary.Transactions, summary.Redemptions, summary.Revenue, openingStock,
+	)
+	return nil
+}
+
+// databaseSink writes generated rows with batched inserts.
+type databaseSink struct {
+	db        *gorm.DB
+	log       *logrus.Logger
+	batchSize int
+	written   int
+}
+
+func newDatabaseSink(db *gorm.DB, log *logrus.Logger, batchSize int) *databaseSink {
+	return &databaseSink{db: db, log: log, batchSize: batchSize}
+}
+
+func (s *databaseSink) Customers(rows []entity.Customer) error {
+	return (&repository.Repository[entity.Customer]{}).CreateInBatches(s.db, rows, s.batchSize)
+}
+
+func (s *databaseSink) Products(rows []entity.Product) error {
+	return (&repository.Repository[entity.Product]{}).CreateInBatches(s.db, rows, s.batchSize)
+}
+
+func (s *databaseSink) Transactions(rows []entity.Transaction) error {
+	if err := (&repository.Repository[entity.Transaction]{}).CreateInBatches(s.db, rows, s.batchSize); err != nil {
+		return err
+	}
+	s.written += len(rows)
+	s.log.Infof("Inserted %d transactions", s.written)
+	return nil
+}
+
+func (s *databaseSink) Redemptions(rows []entity.Redemption) error {
+	return (&repository.Repository[entity.Redemption]{}).CreateInBatches(s.db, rows, s.batchSize)
+}
+
+func (s *databaseSink) ReceiptSequences(rows []entity.ReceiptSequence) error {
+	return (&repository.Repository[entity.ReceiptSequence]{}).CreateInBatches(s.db, rows, s.batchSize)
+}
@@ -0,0 +1,55 @@
+package command
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+
+	"snack-store-api/internal/constants"
+
+	"github.com/spf13/cobra"
+)
+
+// destructiveFlags adds --yes and --allow-production to a command that
+// deletes or overwrites data.
+func destructiveFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("yes", false, "skip the interactive confirmation")
+	cmd.Flags().Bool("allow-production", false, "allow running when APP_ENV=production")
+}
+
+// guardEnvironment refuses action when APP_ENV=production unless the
+// command was given --allow-production.
+func (ce *CommandExecutor) guardEnvironment(cmd *cobra.Command, action string) error {
+	env := strings.ToLower(strings.TrimSpace(ce.Viper.GetString("APP_ENV")))
+	allowProduction, _ := cmd.Flags().GetBool("allow-production")
+	if env == constants.AppEnvProduction && !allowProduction {
+		return &failure{code: ExitRefused, err: fmt.Errorf("refusing to %s with APP_ENV=%s; pass --allow-production to override", action, env)}
+	}
+	return nil
+}
+
+// confirm enforces the environment guard and asks the operator to type the
+// database name before a destructive action. Without a terminal, --yes is
+// required.
+func (ce *CommandExecutor) confirm(cmd *cobra.Command, action string) error {
+	if err := ce.guardEnvironment(cmd, action); err != nil {
+		return err
+	}
+
+	if yes, _ := cmd.Flags().GetBool("yes"); yes {
+		return nil
+	}
+
+	if !ce.IsTerminal() {
+		return &failure{code: ExitRefused, err: fmt.Errorf("refusing to %s without --yes when not running interactively", action)}
+	}
+
+	database := ce.Viper.GetString("DB_NAME")
+	fmt.Fprintf(cmd.ErrOrStderr(), "This will %s on database %q (APP_ENV=%s).\nType the database name to continue: ", action, database, ce.Viper.GetString("APP_ENV"))
+
+	answer, _ := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+	if strings.TrimSpace(answer) != database {
+		return &failure{code: ExitRefused, err: fmt.Errorf("aborted: confirmation did not match %q", database)}
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package command
+
+import (
+	"fmt"
+	"strconv"
+
+	"snack-store-api/internal/migrations"
+
+	"github.com/spf13/cobra"
+)
+
+func (ce *CommandExecutor) migrateCommand() *cobra.Command {
+	cmd := groupCommand("migrate", "Apply, roll back and inspect SQL migrations")
+
+	up := &cobra.Command{
+		Use:   "up",
+		Short: "Apply every pending migration",
+		Long: `Apply every pending migration in version order, then warn about any drift
+between the entities and the database.
+
+An advisory lock makes concurrent replicas wait for each other.`,
+		Args: cobra.NoArgs,
+		RunE: ce.run(func(cmd *cobra.Command, _ []string) error {
+			return ce.migrateUp()
+		}),
+	}
+
+	down := &cobra.Command{
+		Use:     "down N",
+		Short:   "Roll back the latest N migrations",
+		Example: "  snack-store-api migrate down 1 --yes",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := cobra.ExactArgs(1)(cmd, args); err != nil {
+				return err
+			}
+			if steps, err := strconv.Atoi(args[0]); err != nil || steps <= 0 {
+				return fmt.Errorf("invalid migration count %q", args[0])
+			}
+			return nil
+		},
+		RunE: ce.run(func(cmd *cobra.Command, args []string) error {
+			steps, _ := strconv.Atoi(args[0])
+			if err := ce.confirm(cmd, fmt.Sprintf("roll back %d migration(s)", steps)); err != nil {
+				return err
+			}
+
+			db, err := ce.database()
+			if err != nil {
+				return err
+			}
+			migrator, err := migrations.NewMigrator(db, ce.Log)
+			if err != nil {
+				return fmt.Errorf("failed to load migrations: %w", err)
+			}
+			rolledBack, err := migrator.Down(steps)
+			if err != nil {
+				return fmt.Errorf("migration rollback failed: %w", err)
+			}
+			ce.Log.Infof("Migration rollback completed: %d rolled back", rolledBack)
+			return nil
+		}),
+	}
+	destructiveFlags(down)
+
+	status := &cobra.Command{
+		Use:   "status",
+		Short: "List migrations with their applied time",
+		Args:  cobra.NoArgs,
+		RunE: ce.run(func(cmd *cobra.Command, _ []string) error {
+			db, err := ce.database()
+			if err != nil {
+				return err
+			}
+			migrator, err := migrations.NewMigrator(db, ce.Log)
+			if err != nil {
+				return fmt.Errorf("failed to load migrations: %w", err)
+			}
+			statuses, err := migrator.Status()
+			if err != nil {
+				return fmt.Errorf("failed to read migration status: %w", err)
+			}
+
+			for _, status := range statuses {
+				state := "pending"
+				if status.AppliedAt != nil {
+					state = "applied " + status.AppliedAt.Format("2006-01-02 15:04:05 -0700")
+				}
+				if status.Missing {
+					state += " (no file in this build)"
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "%04d_%s: %s\n", status.Version, status.Name, state)
+			}
+			return nil
+		}),
+	}
+
+	check := &cobra.Command{
+		Use:   "check",
+		Short: "Fail when the database schema has drifted from the entities",
+		Args:  cobra.NoArgs,
+		RunE: ce.run(func(cmd *cobra.Command, _ []string) error {
+			db, err := ce.database()
+			if err != nil {
+				return err
+			}
+			drift, err := migrations.CheckDrift(db)
+			if err != nil {
+				return fmt.Errorf("failed to check schema drift: %w", err)
+			}
+			for _, line := range drift {
+				fmt.Fprintf(cmd.OutOrStdout(), "drift: %s\n", line)
+			}
+			if len(drift) > 0 {
+				return fmt.Errorf("schema drift check failed: %d differences", len(drift))
+			}
+			ce.Log.Info("Schema matches entities")
+			return nil
+		}),
+	}
+
+	cmd.AddCommand(up, down, status, check)
+	return cmd
+}
+
+func (ce *CommandExecutor) migrateUp() error {
+	db, err := ce.database()
+	if err != nil {
+		return err
+	}
+	migrator, err := migrations.NewMigrator(db, ce.Log)
+	if err != nil {
+		return fmt.Errorf("failed to load migrations: %w", err)
+	}
+	applied, err := migrator.Up()
+	if err != nil {
+		return fmt.Errorf("migration failed: %w", err)
+	}
+	ce.Log.Infof("Migration completed: %d applied", applied)
+
+	// Drift after a successful up means an entity changed without a
+	// matching migration; warn so the server still starts.
+	drift, err := migrations.CheckDrift(db)
+	if err != nil {
+		ce.Log.Warnf("Failed to check schema drift : %+v", err)
+		return nil
+	}
+	for _, line := range drift {
+		ce.Log.Warnf("Schema drift: %s", line)
+	}
+	return nil
+}
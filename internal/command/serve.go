@@ -0,0 +1,64 @@
+package command
+
+import (
+	"fmt"
+
+	"snack-store-api/internal/config"
+
+	"github.com/spf13/cobra"
+)
+
+func (ce *CommandExecutor) serveCommand() *cobra.Command {
+	var migrate bool
+
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Start the HTTP server",
+		Long: `Start the HTTP server on PORT.
+
+Connects to PostgreSQL and, unless CACHE_DRIVER=memory, to Redis.`,
+		Example: `  snack-store-api serve
+  snack-store-api serve --migrate`,
+		Args: cobra.NoArgs,
+		RunE: ce.run(func(cmd *cobra.Command, _ []string) error {
+			return ce.serve(migrate)
+		}),
+	}
+	cmd.Flags().BoolVar(&migrate, "migrate", false, "apply pending migrations before listening")
+
+	return cmd
+}
+
+func (ce *CommandExecutor) serve(migrate bool) error {
+	db, err := ce.database()
+	if err != nil {
+		return err
+	}
+	if migrate {
+		if err := ce.migrateUp(); err != nil {
+			return err
+		}
+	}
+
+	redisClient := config.NewRedis(ce.Viper, ce.Log)
+	cacheClient := config.NewCache(ce.Viper, ce.Log, redisClient)
+	defer cacheClient.Close()
+	validate := config.NewValidator()
+	router := config.NewGin(ce.Log)
+
+	config.Bootstrap(&config.BootstrapConfig{
+		DB:       db,
+		Router:   router,
+		Log:      ce.Log,
+		Validate: validate,
+		Viper:    ce.Viper,
+		Cache:    cacheClient,
+		Redis:    redisClient,
+	})
+
+	webPort := ce.Viper.GetInt("PORT")
+	if err := router.Run(fmt.Sprintf(":%d", webPort)); err != nil {
+		return fmt.Errorf("failed to start server: %w", err)
+	}
+	return nil
+}
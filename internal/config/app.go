@@ -22,34 +22,48 @@ type BootstrapConfig struct {
 	Log      *logrus.Logger
 	Validate *validator.Validate
 	Viper    *viper.Viper
-	Cache    cache.Cache
+	Cache    *cache.InstrumentedCache
 	Redis    *redis.Client
 }
 
 func Bootstrap(config *BootstrapConfig) {
 	// Setup repositories
-	customerRepository := repository.NewCustomerRepository(config.Log)
+	storeLocation := NewStoreLocation(config.Viper, config.Log)
+	customerRepository := repository.NewCustomerRepository(config.Log, storeLocation)
 	productRepository := repository.NewProductRepository(config.Log)
 	transactionRepository := repository.NewTransactionRepository(config.Log)
 	redemptionRepository := repository.NewRedemptionRepository(config.Log)
-	reportRepository := repository.NewReportRepository(config.Log)
+	shiftRepository := repository.NewShiftRepository(config.Log)
+
+	// Setup policies
+	taxPolicy := NewTaxPolicy(config.Viper, config.Log)
+	reportRepository := repository.NewReportRepository(config.Log, storeLocation)
+	summaryRepository := repository.NewDailySalesSummaryRepository(config.Log, storeLocation)
+	shiftRequired := config.Viper.GetBool("SHIFT_REQUIRED")
+	receiptRenderer := NewReceiptRenderer(config.Viper, storeLocation)
+	cacheLoader := NewCacheLoader(config.Viper, config.Log, config.Cache)
 
 	// Setup use cases
 	customerUseCase := usecase.NewCustomerUseCase(config.DB, config.Log, customerRepository)
-	productUseCase := usecase.NewProductUseCase(config.DB, config.Log, productRepository, config.Cache)
-	transactionUseCase := usecase.NewTransactionUseCase(config.DB, config.Log, customerRepository, productRepository, transactionRepository, config.Cache)
-	redemptionUseCase := usecase.NewRedemptionUseCase(config.DB, config.Log, customerRepository, productRepository, redemptionRepository, config.Cache)
-	reportUseCase := usecase.NewReportUseCase(config.DB, config.Log, reportRepository, config.Cache)
+	productUseCase := usecase.NewProductUseCase(config.DB, config.Log, productRepository, config.Cache, cacheLoader)
+	transactionUseCase := usecase.NewTransactionUseCase(config.DB, config.Log, customerRepository, productRepository, transactionRepository, shiftRepository, summaryRepository, config.Cache, taxPolicy, storeLocation, shiftRequired)
+	redemptionUseCase := usecase.NewRedemptionUseCase(config.DB, config.Log, customerRepository, productRepository, redemptionRepository, shiftRepository, config.Cache, shiftRequired)
+	reportUseCase := usecase.NewReportUseCase(config.DB, config.Log, reportRepository, transactionRepository, cacheLoader, storeLocation)
+	shiftUseCase := usecase.NewShiftUseCase(config.DB, config.Log, shiftRepository)
+	cacheUseCase := usecase.NewCacheUseCase(config.Log, config.Cache, productUseCase, reportUseCase)
 
 	// Setup controllers
 	customerController := http.NewCustomerController(customerUseCase, config.Log, config.Validate)
 	productController := http.NewProductController(productUseCase, config.Log, config.Validate)
-	transactionController := http.NewTransactionController(transactionUseCase, config.Log, config.Validate)
+	transactionController := http.NewTransactionController(transactionUseCase, config.Log, config.Validate, receiptRenderer)
 	redemptionController := http.NewRedemptionController(redemptionUseCase, config.Log, config.Validate)
 	reportController := http.NewReportController(reportUseCase, config.Log, config.Validate)
+	shiftController := http.NewShiftController(shiftUseCase, config.Log, config.Validate)
+	cacheController := http.NewCacheController(cacheUseCase, config.Log, config.Validate)
 
 	// Setup middleware
 	rateLimiterMiddleware := middleware.NewRateLimiter(config.Viper, config.Redis)
+	adminAuthMiddleware := middleware.NewAdminAuth(config.Viper.GetString("ADMIN_TOKEN"))
 
 	// Setup routes
 	routeConfig := route.RouteConfig{
@@ -59,7 +73,10 @@ func Bootstrap(config *BootstrapConfig) {
 		TransactionController: transactionController,
 		RedemptionController:  redemptionController,
 		ReportController:      reportController,
+		ShiftController:       shiftController,
+		CacheController:       cacheController,
 		RateLimiter:           rateLimiterMiddleware,
+		AdminAuth:             adminAuthMiddleware,
 	}
 	routeConfig.Setup()
 }
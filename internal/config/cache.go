@@ -0,0 +1,67 @@
+package config
+
+import (
+	"time"
+
+	"snack-store-api/internal/cache"
+	"snack-store-api/internal/constants"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+// NewCache returns the instrumented application cache, see newCacheStore for
+// how the backing store is chosen.
+func NewCache(viper *viper.Viper, log *logrus.Logger, redisClient *redis.Client) *cache.InstrumentedCache {
+	return cache.NewInstrumentedCache(newCacheStore(viper, log, redisClient))
+}
+
+// newCacheStore returns a Redis backed cache when a client is available and
+// an in-process LRU cache otherwise. With CACHE_L1_TTL > 0 (seconds) Redis is
+// fronted by a local L1 kept coherent across replicas through pub/sub.
+func newCacheStore(viper *viper.Viper, log *logrus.Logger, redisClient *redis.Client) cache.Cache {
+	if redisClient != nil {
+		redisCache := cache.NewRedisCache(redisClient)
+
+		l1TTL := viper.GetInt("CACHE_L1_TTL")
+		if l1TTL <= 0 {
+			return redisCache
+		}
+
+		l1MaxEntries := viper.GetInt("CACHE_L1_MAX_ENTRIES")
+		if l1MaxEntries <= 0 {
+			l1MaxEntries = constants.DefaultL1CacheMaxEntries
+		}
+
+		log.Infof("Using two-tier cache (L1 %ds, max %d entries)", l1TTL, l1MaxEntries)
+		return cache.NewTieredCache(
+			cache.NewMemoryCache(l1MaxEntries),
+			redisCache,
+			time.Duration(l1TTL)*time.Second,
+			redisClient,
+			constants.CacheInvalidationChannel,
+			log,
+		)
+	}
+
+	maxEntries := viper.GetInt("CACHE_MEMORY_MAX_ENTRIES")
+	if maxEntries <= 0 {
+		maxEntries = constants.DefaultMemoryCacheMaxEntries
+	}
+
+	log.Infof("Using in-memory cache (max %d entries)", maxEntries)
+	return cache.NewMemoryCache(maxEntries)
+}
+
+// NewCacheLoader wraps the cache with request coalescing. CACHE_STALE_TTL (in
+// seconds, 0 disables) keeps expired values servable while they refresh.
+func NewCacheLoader(viper *viper.Viper, log *logrus.Logger, cacheStore cache.Cache) *cache.Loader {
+	staleTTL := viper.GetInt("CACHE_STALE_TTL")
+	if staleTTL < 0 {
+		log.Warnf("Invalid CACHE_STALE_TTL %d, disabling stale-while-revalidate", staleTTL)
+		staleTTL = 0
+	}
+
+	return cache.NewLoader(cacheStore, log, time.Duration(staleTTL)*time.Second)
+}
@@ -11,7 +11,9 @@ import (
 	"gorm.io/gorm/logger"
 )
 
-func NewDatabase(viper *viper.Viper, log *logrus.Logger) *gorm.DB {
+// NewDatabase opens the connection pool and checks that PostgreSQL is
+// reachable.
+func NewDatabase(viper *viper.Viper, log *logrus.Logger) (*gorm.DB, error) {
 	username := viper.GetString("DB_USERNAME")
 	password := viper.GetString("DB_PASSWORD")
 	host := viper.GetString("DB_HOST")
@@ -33,19 +35,19 @@ func NewDatabase(viper *viper.Viper, log *logrus.Logger) *gorm.DB {
 		}),
 	})
 	if err != nil {
-		log.Fatalf("failed to connect database: %v", err)
+		return nil, fmt.Errorf("failed to connect database: %w", err)
 	}
 
 	connection, err := db.DB()
 	if err != nil {
-		log.Fatalf("failed to connect database: %v", err)
+		return nil, fmt.Errorf("failed to connect database: %w", err)
 	}
 
 	connection.SetMaxIdleConns(idleConnection)
 	connection.SetMaxOpenConns(maxConnection)
 	connection.SetConnMaxLifetime(time.Second * time.Duration(maxLifeTimeConnection))
 
-	return db
+	return db, nil
 }
 
 type logrusWriter struct {
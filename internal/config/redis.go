@@ -1,22 +1,49 @@
 package config
 
 import (
+	"context"
 	"fmt"
+	"strings"
+
+	"snack-store-api/internal/constants"
 
 	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
 )
 
-func NewRedis(viper *viper.Viper) *redis.Client {
+// NewRedis connects to Redis unless CACHE_DRIVER selects the in-memory cache.
+// It returns nil when Redis is disabled or unreachable at startup, so the app
+// can still run on in-process fallbacks.
+func NewRedis(viper *viper.Viper, log *logrus.Logger) *redis.Client {
+	driver := strings.ToLower(strings.TrimSpace(viper.GetString("CACHE_DRIVER")))
+	if driver == constants.CacheDriverMemory {
+		return nil
+	}
+	if driver != constants.CacheDriverRedis {
+		log.Warnf("Unknown CACHE_DRIVER %q, using %s", driver, constants.CacheDriverRedis)
+	}
+
 	host := viper.GetString("REDIS_HOST")
 	port := viper.GetInt("REDIS_PORT")
 	password := viper.GetString("REDIS_PASSWORD")
 	db := viper.GetInt("REDIS_DB")
 
 	address := fmt.Sprintf("%s:%d", host, port)
-	return redis.NewClient(&redis.Options{
+	client := redis.NewClient(&redis.Options{
 		Addr:     address,
 		Password: password,
 		DB:       db,
 	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), constants.RedisPingTimeout)
+	defer cancel()
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		log.Warnf("Redis at %s is unreachable, falling back to in-memory cache and rate limiter : %+v", address, err)
+		_ = client.Close()
+		return nil
+	}
+
+	return client
 }
@@ -0,0 +1,30 @@
+package config
+
+import (
+	"time"
+
+	"snack-store-api/internal/receipt"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+func NewStoreLocation(viper *viper.Viper, log *logrus.Logger) *time.Location {
+	name := viper.GetString("STORE_TIMEZONE")
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		log.Warnf("Invalid STORE_TIMEZONE '%s', using UTC : %+v", name, err)
+		return time.UTC
+	}
+
+	return location
+}
+
+func NewReceiptRenderer(viper *viper.Viper, location *time.Location) *receipt.Renderer {
+	return receipt.NewRenderer(receipt.Store{
+		Name:    viper.GetString("STORE_NAME"),
+		Address: viper.GetString("STORE_ADDRESS"),
+		Phone:   viper.GetString("STORE_PHONE"),
+		Footer:  viper.GetString("RECEIPT_FOOTER"),
+	}, location)
+}
@@ -0,0 +1,66 @@
+package config
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"snack-store-api/internal/entity"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+func NewTaxPolicy(viper *viper.Viper, log *logrus.Logger) *entity.TaxPolicy {
+	policy := &entity.TaxPolicy{
+		RatesBps:       map[string]int{},
+		PriceInclusive: viper.GetBool("TAX_PRICE_INCLUSIVE"),
+	}
+
+	defaultRate, err := parseTaxRate(viper.GetString("TAX_RATE"))
+	if err != nil {
+		log.Warnf("Invalid TAX_RATE, tax disabled : %+v", err)
+	}
+	policy.DefaultRateBps = defaultRate
+
+	for _, pair := range strings.Split(viper.GetString("TAX_RATES"), ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		productType, rateStr, found := strings.Cut(pair, ":")
+		if !found || strings.TrimSpace(productType) == "" {
+			log.Warnf("Invalid TAX_RATES entry '%s'", pair)
+			continue
+		}
+
+		rate, err := parseTaxRate(rateStr)
+		if err != nil {
+			log.Warnf("Invalid TAX_RATES entry '%s' : %+v", pair, err)
+			continue
+		}
+
+		policy.RatesBps[entity.NormalizeProductType(productType)] = rate
+	}
+
+	return policy
+}
+
+func parseTaxRate(percent string) (int, error) {
+	trimmed := strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(percent), "%"))
+	if trimmed == "" {
+		return 0, nil
+	}
+
+	value, err := strconv.ParseFloat(trimmed, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	if value < 0 {
+		return 0, strconv.ErrRange
+	}
+
+	return int(math.Round(value * 100)), nil
+}
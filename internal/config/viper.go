@@ -11,6 +11,7 @@ func NewViper() *viper.Viper {
 	config := viper.New()
 
 	config.SetDefault("APP_NAME", "snack-store-api")
+	config.SetDefault("APP_ENV", "development")
 	config.SetDefault("PORT", 8080)
 	config.SetDefault("LOG_LEVEL", "info")
 	config.SetDefault("DB_HOST", "localhost")
@@ -23,7 +24,22 @@ func NewViper() *viper.Viper {
 	config.SetDefault("REDIS_PORT", 6379)
 	config.SetDefault("REDIS_PASSWORD", "")
 	config.SetDefault("REDIS_DB", 0)
+	config.SetDefault("CACHE_DRIVER", "redis")
+	config.SetDefault("CACHE_MEMORY_MAX_ENTRIES", 10000)
+	config.SetDefault("CACHE_STALE_TTL", 0)
+	config.SetDefault("CACHE_L1_TTL", 0)
+	config.SetDefault("CACHE_L1_MAX_ENTRIES", 1000)
+	config.SetDefault("ADMIN_TOKEN", "")
 	config.SetDefault("RATE_LIMIT", "60-M")
+	config.SetDefault("TAX_RATE", "11")
+	config.SetDefault("TAX_RATES", "")
+	config.SetDefault("TAX_PRICE_INCLUSIVE", true)
+	config.SetDefault("STORE_NAME", "Snack Store")
+	config.SetDefault("STORE_ADDRESS", "")
+	config.SetDefault("STORE_PHONE", "")
+	config.SetDefault("STORE_TIMEZONE", "Asia/Jakarta")
+	config.SetDefault("RECEIPT_FOOTER", "Thank you for shopping!")
+	config.SetDefault("SHIFT_REQUIRED", false)
 
 	config.SetConfigFile(".env")
 
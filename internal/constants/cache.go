@@ -11,3 +11,45 @@ const (
 	ProductCacheTTL = 5 * time.Minute
 	ReportCacheTTL  = 2 * time.Minute
 )
+
+const (
+	CacheDriverRedis  = "redis"
+	CacheDriverMemory = "memory"
+)
+
+const DefaultMemoryCacheMaxEntries = 10000
+
+// DefaultL1CacheMaxEntries sizes the in-process tier of the two-tier cache;
+// it only needs to hold hot keys.
+const DefaultL1CacheMaxEntries = 1000
+
+// CacheInvalidationChannel is the Redis pub/sub channel replicas use to drop
+// keys from each other's L1.
+const CacheInvalidationChannel = "cache:invalidate"
+
+// RedisPingTimeout bounds the startup connectivity check before falling back
+// to the in-memory cache.
+const RedisPingTimeout = 2 * time.Second
+
+// ReportVersionKeyPrefix namespaces the per day/month/year stamps that are
+// part of every report cache key; retiring one retires the reports covering
+// it. The stamps never expire, and one that is evicted is simply re-created.
+const ReportVersionKeyPrefix = "report:version:"
+
+// MaxReportVersionBuckets caps how many version stamps one report period
+// reads; longer lists fall back to ReportVersionAllBucket.
+const MaxReportVersionBuckets = 64
+
+// ReportVersionAllBucket is retired by every sale and stands in for periods
+// that would need more than MaxReportVersionBuckets stamps.
+const ReportVersionAllBucket = "all"
+
+// CacheFlushNamespaces maps the namespaces the admin API may flush to their
+// key prefix. Report version counters are deliberately not flushable.
+var CacheFlushNamespaces = map[string]string{
+	"products": ProductCacheKeyPrefix,
+	"reports":  ReportCacheKeyPrefix,
+}
+
+// MaxCacheWarmDays bounds how many days one warm-up request may load.
+const MaxCacheWarmDays = 31
@@ -0,0 +1,6 @@
+package constants
+
+const (
+	AppEnvDevelopment = "development"
+	AppEnvProduction  = "production"
+)
@@ -0,0 +1,13 @@
+package constants
+
+const (
+	DefaultGenerateCustomers    = 1000
+	DefaultGenerateProducts     = 60
+	DefaultGenerateTransactions = 50000
+	DefaultGenerateDays         = 365
+	DefaultGenerateBatchSize    = 1000
+)
+
+// MaxGenerateBatchSize keeps a batched insert of transactions under the
+// PostgreSQL limit of 65535 bind parameters.
+const MaxGenerateBatchSize = 3000
@@ -5,3 +5,5 @@ const (
 	RateLimitRemainingHeader = "X-RateLimit-Remaining"
 	RateLimitResetHeader     = "X-RateLimit-Reset"
 )
+
+const AdminTokenHeader = "X-Admin-Token"
@@ -4,3 +4,8 @@ const (
 	DefaultPage     = 1
 	DefaultPageSize = 10
 )
+
+const (
+	SortAsc  = "asc"
+	SortDesc = "desc"
+)
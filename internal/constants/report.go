@@ -1,3 +1,39 @@
 package constants
 
 const ReportLastTransactionLimit = 10
+
+// ReportQueryParallelism bounds how many report sub-queries run at once per
+// request, keeping a single report from draining the connection pool.
+const ReportQueryParallelism = 3
+
+const (
+	GranularityDay   = "day"
+	GranularityWeek  = "week"
+	GranularityMonth = "month"
+)
+
+const (
+	BreakdownFlavor  = "flavor"
+	BreakdownSize    = "size"
+	BreakdownType    = "type"
+	BreakdownProduct = "product"
+)
+
+const (
+	BreakdownSortRevenue = "revenue"
+	BreakdownSortQty     = "qty"
+)
+
+const DefaultBreakdownLimit = 10
+
+const (
+	DefaultCohortMonths = 6
+	DefaultChurnDays    = 60
+)
+
+const ReportTopRedeemedLimit = 5
+
+const (
+	DefaultVelocityDays  = 30
+	DefaultDeadStockDays = 60
+)
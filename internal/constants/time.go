@@ -4,5 +4,7 @@ import "time"
 
 const (
 	DateLayout     = "2006-01-02"
+	MonthLayout    = "2006-01"
+	YearLayout     = "2006"
 	DateTimeLayout = time.RFC3339
 )
@@ -0,0 +1,115 @@
+package http
+
+import (
+	"net/http"
+	"strings"
+
+	"snack-store-api/internal/messages"
+	"snack-store-api/internal/model"
+	"snack-store-api/internal/usecase"
+	"snack-store-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+)
+
+type CacheController struct {
+	Log      *logrus.Logger
+	UseCase  *usecase.CacheUseCase
+	Validate *validator.Validate
+}
+
+func NewCacheController(
+	useCase *usecase.CacheUseCase,
+	logger *logrus.Logger,
+	validate *validator.Validate,
+) *CacheController {
+	return &CacheController{
+		Log:      logger,
+		UseCase:  useCase,
+		Validate: validate,
+	}
+}
+
+func (c *CacheController) Stats(ctx *gin.Context) {
+	response := c.UseCase.Stats(ctx.Request.Context())
+
+	res := utils.SuccessResponse(messages.CacheStatsFetched, response)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *CacheController) Inspect(ctx *gin.Context) {
+	request := &model.CacheKeyRequest{
+		Key: strings.TrimSpace(ctx.Query("key")),
+	}
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Validation failed : %+v", err)
+		message := utils.TranslateValidationError(c.Validate, err)
+		utils.HandleHTTPError(ctx, utils.Error(message, http.StatusBadRequest, err))
+		return
+	}
+
+	response, err := c.UseCase.Inspect(ctx.Request.Context(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to inspect cache key : %+v", err)
+		utils.HandleHTTPError(ctx, err)
+		return
+	}
+
+	res := utils.SuccessResponse(messages.CacheKeyFetched, response)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *CacheController) Flush(ctx *gin.Context) {
+	request := &model.FlushCacheRequest{
+		Namespace: strings.TrimSpace(ctx.Param("namespace")),
+	}
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Validation failed : %+v", err)
+		message := utils.TranslateValidationError(c.Validate, err)
+		utils.HandleHTTPError(ctx, utils.Error(message, http.StatusBadRequest, err))
+		return
+	}
+
+	response, err := c.UseCase.Flush(ctx.Request.Context(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to flush cache : %+v", err)
+		utils.HandleHTTPError(ctx, err)
+		return
+	}
+
+	res := utils.SuccessResponse(messages.CacheFlushed, response)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *CacheController) Warm(ctx *gin.Context) {
+	request := new(model.WarmCacheRequest)
+	if err := ctx.ShouldBindJSON(request); err != nil {
+		c.Log.Warnf("Failed to parse request body : %+v", err)
+		utils.HandleHTTPError(ctx, utils.Error(messages.FailedDataFromBody, http.StatusBadRequest, err))
+		return
+	}
+
+	request.Start = strings.TrimSpace(request.Start)
+	request.End = strings.TrimSpace(request.End)
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Validation failed : %+v", err)
+		message := utils.TranslateValidationError(c.Validate, err)
+		utils.HandleHTTPError(ctx, utils.Error(message, http.StatusBadRequest, err))
+		return
+	}
+
+	response, err := c.UseCase.Warm(ctx.Request.Context(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to warm cache : %+v", err)
+		utils.HandleHTTPError(ctx, err)
+		return
+	}
+
+	res := utils.SuccessResponse(messages.CacheWarmed, response)
+	ctx.JSON(http.StatusOK, res)
+}
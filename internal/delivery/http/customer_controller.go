@@ -1,11 +1,17 @@
 package http
 
 import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
 	"net/http"
+	"strings"
+	"time"
 
 	"snack-store-api/internal/constants"
 	"snack-store-api/internal/messages"
 	"snack-store-api/internal/model"
+	"snack-store-api/internal/model/converter"
 	"snack-store-api/internal/usecase"
 	"snack-store-api/internal/utils"
 
@@ -49,6 +55,11 @@ func (c *CustomerController) List(ctx *gin.Context) {
 	request.Page = page
 	request.PageSize = pageSize
 
+	cursor, cursorMode := ctx.GetQuery("cursor")
+	request.Cursor = cursor
+	request.WithCount = strings.EqualFold(strings.TrimSpace(ctx.Query("with_count")), "true")
+	request.Segment = strings.ToLower(strings.TrimSpace(ctx.Query("segment")))
+
 	if err := c.Validate.Struct(request); err != nil {
 		c.Log.Warnf("Validation failed : %+v", err)
 		message := utils.TranslateValidationError(c.Validate, err)
@@ -56,6 +67,19 @@ func (c *CustomerController) List(ctx *gin.Context) {
 		return
 	}
 
+	if cursorMode {
+		response, cursor, err := c.UseCase.ListByCursor(ctx.Request.Context(), request)
+		if err != nil {
+			c.Log.Warnf("Failed to get customers : %+v", err)
+			utils.HandleHTTPError(ctx, err)
+			return
+		}
+
+		res := utils.SuccessWithCursorResponse(messages.CustomersFetched, response, cursor)
+		ctx.JSON(http.StatusOK, res)
+		return
+	}
+
 	response, paging, err := c.UseCase.List(ctx.Request.Context(), request)
 	if err != nil {
 		c.Log.Warnf("Failed to get customers : %+v", err)
@@ -66,3 +90,48 @@ func (c *CustomerController) List(ctx *gin.Context) {
 	res := utils.SuccessWithPaginationResponse(messages.CustomersFetched, response, paging)
 	ctx.JSON(http.StatusOK, res)
 }
+
+func (c *CustomerController) Export(ctx *gin.Context) {
+	request := new(model.GetCustomerRequest)
+	request.Segment = strings.ToLower(strings.TrimSpace(ctx.Query("segment")))
+	request.Page = constants.DefaultPage
+	request.PageSize = constants.DefaultPageSize
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Validation failed : %+v", err)
+		message := utils.TranslateValidationError(c.Validate, err)
+		utils.HandleHTTPError(ctx, utils.Error(message, http.StatusBadRequest, err))
+		return
+	}
+
+	rows, scores, err := c.UseCase.Scored(ctx.Request.Context(), request.Segment)
+	if err != nil {
+		c.Log.Warnf("Failed to export customer segments : %+v", err)
+		utils.HandleHTTPError(ctx, err)
+		return
+	}
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	err = writer.Write(converter.CustomerRFMCSVHeader)
+	for i := 0; err == nil && i < len(rows); i++ {
+		err = writer.Write(converter.CustomerRFMToCSVRecord(&rows[i], scores[i]))
+	}
+	writer.Flush()
+	if err == nil {
+		err = writer.Error()
+	}
+	if err != nil {
+		c.Log.Warnf("Failed to write customer segments csv : %+v", err)
+		utils.HandleHTTPError(ctx, utils.Error(messages.ErrExport, http.StatusInternalServerError, err))
+		return
+	}
+
+	segment := request.Segment
+	if segment == "" {
+		segment = "all"
+	}
+	filename := fmt.Sprintf("customers-%s-%s.csv", segment, time.Now().Format("20060102"))
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	ctx.Data(http.StatusOK, "text/csv; charset=utf-8", buf.Bytes())
+}
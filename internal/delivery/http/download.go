@@ -0,0 +1,42 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// downloadWriter sends the attachment headers on the first write only. Until
+// then nothing has reached the client, so an error can still be answered with
+// a regular JSON error response.
+type downloadWriter struct {
+	ctx         *gin.Context
+	contentType string
+	filename    string
+	started     bool
+}
+
+func newDownloadWriter(ctx *gin.Context, contentType, filename string) *downloadWriter {
+	return &downloadWriter{
+		ctx:         ctx,
+		contentType: contentType,
+		filename:    filename,
+	}
+}
+
+func (w *downloadWriter) Write(p []byte) (int, error) {
+	if !w.started {
+		w.started = true
+		w.ctx.Header("Content-Type", w.contentType)
+		w.ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", w.filename))
+		w.ctx.Status(http.StatusOK)
+	}
+
+	return w.ctx.Writer.Write(p)
+}
+
+// Started reports whether the response has already been committed.
+func (w *downloadWriter) Started() bool {
+	return w.started
+}
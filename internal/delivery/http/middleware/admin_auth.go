@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"snack-store-api/internal/constants"
+	"snack-store-api/internal/messages"
+	"snack-store-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NewAdminAuth guards admin routes with a shared token sent in the
+// X-Admin-Token header. Without a configured token the routes are disabled.
+func NewAdminAuth(token string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if token == "" {
+			utils.HandleHTTPError(ctx, utils.Error(messages.ErrAdminDisabled, http.StatusForbidden, nil))
+			return
+		}
+
+		provided := ctx.GetHeader(constants.AdminTokenHeader)
+		if subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+			utils.HandleHTTPError(ctx, utils.Error(messages.Unauthorized, http.StatusUnauthorized, nil))
+			return
+		}
+
+		ctx.Next()
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/redis/go-redis/v9"
 	"github.com/spf13/viper"
 	"github.com/ulule/limiter/v3"
+	memorystore "github.com/ulule/limiter/v3/drivers/store/memory"
 	redisstore "github.com/ulule/limiter/v3/drivers/store/redis"
 )
 
@@ -22,15 +23,25 @@ func NewRateLimiter(viper *viper.Viper, redis *redis.Client) gin.HandlerFunc {
 	return NewRateLimiterWithRate(rateStr, redis)
 }
 
+// NewRateLimiterWithRate counts requests in Redis, or in process memory when
+// redis is nil (CACHE_DRIVER=memory or Redis unreachable at startup).
 func NewRateLimiterWithRate(rateStr string, redis *redis.Client) gin.HandlerFunc {
 	rate := parseRate(rateStr)
 
-	store, err := redisstore.NewStoreWithOptions(redis, limiter.StoreOptions{
+	storeOptions := limiter.StoreOptions{
 		Prefix:   "rate_limiter",
 		MaxRetry: 3,
-	})
-	if err != nil {
-		panic(err)
+	}
+
+	var store limiter.Store
+	if redis == nil {
+		store = memorystore.NewStoreWithOptions(storeOptions)
+	} else {
+		redisStore, err := redisstore.NewStoreWithOptions(redis, storeOptions)
+		if err != nil {
+			panic(err)
+		}
+		store = redisStore
 	}
 
 	limiterInstance := limiter.New(store, rate)
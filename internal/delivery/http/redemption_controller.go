@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"strings"
 
+	"snack-store-api/internal/constants"
 	"snack-store-api/internal/messages"
 	"snack-store-api/internal/model"
 	"snack-store-api/internal/usecase"
@@ -61,3 +62,84 @@ func (c *RedemptionController) Create(ctx *gin.Context) {
 	res := utils.SuccessResponse(messages.RedemptionCreated, response)
 	ctx.JSON(http.StatusCreated, res)
 }
+
+func (c *RedemptionController) List(ctx *gin.Context) {
+	request := new(model.GetRedemptionRequest)
+	request.Start = strings.TrimSpace(ctx.Query("start"))
+	request.End = strings.TrimSpace(ctx.Query("end"))
+	request.CustomerName = strings.TrimSpace(ctx.Query("customer"))
+	request.ProductID = strings.TrimSpace(ctx.Query("product_id"))
+	request.Size = strings.TrimSpace(ctx.Query("size"))
+	request.Sort = strings.ToLower(strings.TrimSpace(ctx.Query("sort")))
+
+	page, pageSize, err := utils.ParsePagination(
+		ctx.Query("page"),
+		ctx.Query("page_size"),
+		constants.DefaultPage,
+		constants.DefaultPageSize,
+	)
+	if err != nil {
+		c.Log.Warnf("Failed to parse pagination : %+v", err)
+		utils.HandleHTTPError(ctx, utils.Error(messages.FailedInputFormat, http.StatusBadRequest, err))
+		return
+	}
+
+	request.Page = page
+	request.PageSize = pageSize
+
+	cursor, cursorMode := ctx.GetQuery("cursor")
+	request.Cursor = cursor
+	request.WithCount = strings.EqualFold(strings.TrimSpace(ctx.Query("with_count")), "true")
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Validation failed : %+v", err)
+		message := utils.TranslateValidationError(c.Validate, err)
+		utils.HandleHTTPError(ctx, utils.Error(message, http.StatusBadRequest, err))
+		return
+	}
+
+	if cursorMode {
+		response, cursor, err := c.UseCase.ListByCursor(ctx.Request.Context(), request)
+		if err != nil {
+			c.Log.Warnf("Failed to get redemptions : %+v", err)
+			utils.HandleHTTPError(ctx, err)
+			return
+		}
+
+		res := utils.SuccessWithCursorResponse(messages.RedemptionsFetched, response, cursor)
+		ctx.JSON(http.StatusOK, res)
+		return
+	}
+
+	response, paging, err := c.UseCase.List(ctx.Request.Context(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to get redemptions : %+v", err)
+		utils.HandleHTTPError(ctx, err)
+		return
+	}
+
+	res := utils.SuccessWithPaginationResponse(messages.RedemptionsFetched, response, paging)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *RedemptionController) Detail(ctx *gin.Context) {
+	request := new(model.GetRedemptionDetailRequest)
+	request.ID = strings.TrimSpace(ctx.Param("id"))
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Validation failed : %+v", err)
+		message := utils.TranslateValidationError(c.Validate, err)
+		utils.HandleHTTPError(ctx, utils.Error(message, http.StatusBadRequest, err))
+		return
+	}
+
+	response, err := c.UseCase.Detail(ctx.Request.Context(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to get redemption : %+v", err)
+		utils.HandleHTTPError(ctx, err)
+		return
+	}
+
+	res := utils.SuccessResponse(messages.RedemptionFetched, response)
+	ctx.JSON(http.StatusOK, res)
+}
@@ -1,9 +1,12 @@
 package http
 
 import (
+	"fmt"
 	"net/http"
 	"strings"
 
+	"snack-store-api/internal/constants"
+	"snack-store-api/internal/export"
 	"snack-store-api/internal/messages"
 	"snack-store-api/internal/model"
 	"snack-store-api/internal/usecase"
@@ -36,6 +39,9 @@ func (c *ReportController) Transactions(ctx *gin.Context) {
 	request := new(model.ReportTransactionsRequest)
 	request.Start = strings.TrimSpace(ctx.Query("start"))
 	request.End = strings.TrimSpace(ctx.Query("end"))
+	request.Compare = strings.ToLower(strings.TrimSpace(ctx.Query("compare")))
+	request.Format = export.NegotiateFormat(ctx.Query("format"), ctx.GetHeader("Accept"))
+	request.Locale = strings.ToLower(strings.TrimSpace(ctx.DefaultQuery("locale", export.LocaleID)))
 
 	if err := c.Validate.Struct(request); err != nil {
 		c.Log.Warnf("Validation failed : %+v", err)
@@ -44,6 +50,18 @@ func (c *ReportController) Transactions(ctx *gin.Context) {
 		return
 	}
 
+	if request.Format != "" {
+		filename := fmt.Sprintf("report-transactions-%s_%s.%s", request.Start, request.End, request.Format)
+		out := newDownloadWriter(ctx, export.ContentType(request.Format), filename)
+		if err := c.UseCase.ExportTransactions(ctx.Request.Context(), request, request.Format, request.Locale, out); err != nil {
+			c.Log.Warnf("Failed to export report : %+v", err)
+			if !out.Started() {
+				utils.HandleHTTPError(ctx, err)
+			}
+		}
+		return
+	}
+
 	response, err := c.UseCase.Transactions(ctx.Request.Context(), request)
 	if err != nil {
 		c.Log.Warnf("Failed to get report : %+v", err)
@@ -54,3 +72,221 @@ func (c *ReportController) Transactions(ctx *gin.Context) {
 	res := utils.SuccessResponse(messages.ReportFetched, response)
 	ctx.JSON(http.StatusOK, res)
 }
+
+func (c *ReportController) Tax(ctx *gin.Context) {
+	request := new(model.ReportTaxRequest)
+	request.Start = strings.TrimSpace(ctx.Query("start"))
+	request.End = strings.TrimSpace(ctx.Query("end"))
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Validation failed : %+v", err)
+		message := utils.TranslateValidationError(c.Validate, err)
+		utils.HandleHTTPError(ctx, utils.Error(message, http.StatusBadRequest, err))
+		return
+	}
+
+	response, err := c.UseCase.Tax(ctx.Request.Context(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to get tax report : %+v", err)
+		utils.HandleHTTPError(ctx, err)
+		return
+	}
+
+	res := utils.SuccessResponse(messages.ReportFetched, response)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *ReportController) Sales(ctx *gin.Context) {
+	request := new(model.ReportSalesRequest)
+	request.Start = strings.TrimSpace(ctx.Query("start"))
+	request.End = strings.TrimSpace(ctx.Query("end"))
+	request.Granularity = strings.ToLower(strings.TrimSpace(ctx.DefaultQuery("granularity", constants.GranularityDay)))
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Validation failed : %+v", err)
+		message := utils.TranslateValidationError(c.Validate, err)
+		utils.HandleHTTPError(ctx, utils.Error(message, http.StatusBadRequest, err))
+		return
+	}
+
+	response, err := c.UseCase.Sales(ctx.Request.Context(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to get sales report : %+v", err)
+		utils.HandleHTTPError(ctx, err)
+		return
+	}
+
+	res := utils.SuccessResponse(messages.ReportFetched, response)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *ReportController) Breakdown(ctx *gin.Context) {
+	request := new(model.ReportBreakdownRequest)
+	request.Start = strings.TrimSpace(ctx.Query("start"))
+	request.End = strings.TrimSpace(ctx.Query("end"))
+	request.GroupBy = strings.ToLower(strings.TrimSpace(ctx.DefaultQuery("group_by", constants.BreakdownProduct)))
+	request.SortBy = strings.ToLower(strings.TrimSpace(ctx.DefaultQuery("sort_by", constants.BreakdownSortRevenue)))
+
+	limit, err := utils.ParseOptionalInt(ctx.Query("limit"))
+	if err != nil {
+		c.Log.Warnf("Failed to parse limit : %+v", err)
+		utils.HandleHTTPError(ctx, utils.Error(messages.FailedInputFormat, http.StatusBadRequest, err))
+		return
+	}
+	request.Limit = constants.DefaultBreakdownLimit
+	if limit != nil {
+		request.Limit = *limit
+	}
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Validation failed : %+v", err)
+		message := utils.TranslateValidationError(c.Validate, err)
+		utils.HandleHTTPError(ctx, utils.Error(message, http.StatusBadRequest, err))
+		return
+	}
+
+	response, err := c.UseCase.Breakdown(ctx.Request.Context(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to get sales breakdown : %+v", err)
+		utils.HandleHTTPError(ctx, err)
+		return
+	}
+
+	res := utils.SuccessResponse(messages.ReportFetched, response)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *ReportController) Cohorts(ctx *gin.Context) {
+	request := new(model.ReportCohortRequest)
+	request.Start = strings.TrimSpace(ctx.Query("start"))
+	request.End = strings.TrimSpace(ctx.Query("end"))
+
+	months, err := utils.ParseOptionalInt(ctx.Query("months"))
+	if err != nil {
+		c.Log.Warnf("Failed to parse months : %+v", err)
+		utils.HandleHTTPError(ctx, utils.Error(messages.FailedInputFormat, http.StatusBadRequest, err))
+		return
+	}
+	request.Months = constants.DefaultCohortMonths
+	if months != nil {
+		request.Months = *months
+	}
+
+	churnDays, err := utils.ParseOptionalInt(ctx.Query("churn_days"))
+	if err != nil {
+		c.Log.Warnf("Failed to parse churn_days : %+v", err)
+		utils.HandleHTTPError(ctx, utils.Error(messages.FailedInputFormat, http.StatusBadRequest, err))
+		return
+	}
+	request.ChurnDays = constants.DefaultChurnDays
+	if churnDays != nil {
+		request.ChurnDays = *churnDays
+	}
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Validation failed : %+v", err)
+		message := utils.TranslateValidationError(c.Validate, err)
+		utils.HandleHTTPError(ctx, utils.Error(message, http.StatusBadRequest, err))
+		return
+	}
+
+	response, err := c.UseCase.Cohorts(ctx.Request.Context(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to get cohort report : %+v", err)
+		utils.HandleHTTPError(ctx, err)
+		return
+	}
+
+	res := utils.SuccessResponse(messages.ReportFetched, response)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *ReportController) Loyalty(ctx *gin.Context) {
+	request := new(model.ReportLoyaltyRequest)
+	request.Start = strings.TrimSpace(ctx.Query("start"))
+	request.End = strings.TrimSpace(ctx.Query("end"))
+	request.Granularity = strings.ToLower(strings.TrimSpace(ctx.DefaultQuery("granularity", constants.GranularityMonth)))
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Validation failed : %+v", err)
+		message := utils.TranslateValidationError(c.Validate, err)
+		utils.HandleHTTPError(ctx, utils.Error(message, http.StatusBadRequest, err))
+		return
+	}
+
+	response, err := c.UseCase.Loyalty(ctx.Request.Context(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to get loyalty report : %+v", err)
+		utils.HandleHTTPError(ctx, err)
+		return
+	}
+
+	res := utils.SuccessResponse(messages.ReportFetched, response)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *ReportController) Inventory(ctx *gin.Context) {
+	request := new(model.ReportInventoryRequest)
+
+	velocityDays, err := utils.ParseOptionalInt(ctx.Query("velocity_days"))
+	if err != nil {
+		c.Log.Warnf("Failed to parse velocity_days : %+v", err)
+		utils.HandleHTTPError(ctx, utils.Error(messages.FailedInputFormat, http.StatusBadRequest, err))
+		return
+	}
+	request.VelocityDays = constants.DefaultVelocityDays
+	if velocityDays != nil {
+		request.VelocityDays = *velocityDays
+	}
+
+	deadStockDays, err := utils.ParseOptionalInt(ctx.Query("dead_stock_days"))
+	if err != nil {
+		c.Log.Warnf("Failed to parse dead_stock_days : %+v", err)
+		utils.HandleHTTPError(ctx, utils.Error(messages.FailedInputFormat, http.StatusBadRequest, err))
+		return
+	}
+	request.DeadStockDays = constants.DefaultDeadStockDays
+	if deadStockDays != nil {
+		request.DeadStockDays = *deadStockDays
+	}
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Validation failed : %+v", err)
+		message := utils.TranslateValidationError(c.Validate, err)
+		utils.HandleHTTPError(ctx, utils.Error(message, http.StatusBadRequest, err))
+		return
+	}
+
+	response, err := c.UseCase.Inventory(ctx.Request.Context(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to get inventory report : %+v", err)
+		utils.HandleHTTPError(ctx, err)
+		return
+	}
+
+	res := utils.SuccessResponse(messages.ReportFetched, response)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *ReportController) Heatmap(ctx *gin.Context) {
+	request := new(model.ReportHeatmapRequest)
+	request.Start = strings.TrimSpace(ctx.Query("start"))
+	request.End = strings.TrimSpace(ctx.Query("end"))
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Validation failed : %+v", err)
+		message := utils.TranslateValidationError(c.Validate, err)
+		utils.HandleHTTPError(ctx, utils.Error(message, http.StatusBadRequest, err))
+		return
+	}
+
+	response, err := c.UseCase.Heatmap(ctx.Request.Context(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to get sales heatmap : %+v", err)
+		utils.HandleHTTPError(ctx, err)
+		return
+	}
+
+	res := utils.SuccessResponse(messages.ReportFetched, response)
+	ctx.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,13 @@
+package route
+
+import "github.com/gin-gonic/gin"
+
+func (c *RouteConfig) RegisterAdminRoutes(rg *gin.RouterGroup) {
+	admin := rg.Group("/admin")
+	admin.Use(c.AdminAuth)
+
+	admin.GET("/cache/stats", c.CacheController.Stats)
+	admin.GET("/cache/keys", c.CacheController.Inspect)
+	admin.DELETE("/cache/namespaces/:namespace", c.CacheController.Flush)
+	admin.POST("/cache/warm", c.CacheController.Warm)
+}
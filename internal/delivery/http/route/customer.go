@@ -6,4 +6,5 @@ func (c *RouteConfig) RegisterCustomerRoutes(rg *gin.RouterGroup) {
 	customers := rg.Group("/customers")
 
 	customers.GET("", c.CustomerController.List)
+	customers.GET("/export", c.CustomerController.Export)
 }
@@ -6,4 +6,6 @@ func (c *RouteConfig) RegisterRedemptionRoutes(rg *gin.RouterGroup) {
 	redemptions := rg.Group("/redemptions")
 
 	redemptions.POST("", c.RedemptionController.Create)
+	redemptions.GET("", c.RedemptionController.List)
+	redemptions.GET("/:id", c.RedemptionController.Detail)
 }
@@ -6,4 +6,11 @@ func (c *RouteConfig) RegisterReportRoutes(rg *gin.RouterGroup) {
 	reports := rg.Group("/reports")
 
 	reports.GET("/transactions", c.ReportController.Transactions)
+	reports.GET("/tax", c.ReportController.Tax)
+	reports.GET("/sales", c.ReportController.Sales)
+	reports.GET("/breakdown", c.ReportController.Breakdown)
+	reports.GET("/cohorts", c.ReportController.Cohorts)
+	reports.GET("/loyalty", c.ReportController.Loyalty)
+	reports.GET("/inventory", c.ReportController.Inventory)
+	reports.GET("/heatmap", c.ReportController.Heatmap)
 }
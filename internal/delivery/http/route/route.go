@@ -13,7 +13,10 @@ type RouteConfig struct {
 	TransactionController *http.TransactionController
 	RedemptionController  *http.RedemptionController
 	ReportController      *http.ReportController
+	ShiftController       *http.ShiftController
+	CacheController       *http.CacheController
 	RateLimiter           gin.HandlerFunc
+	AdminAuth             gin.HandlerFunc
 }
 
 func (c *RouteConfig) Setup() {
@@ -25,5 +28,7 @@ func (c *RouteConfig) Setup() {
 	c.RegisterTransactionRoutes(api)
 	c.RegisterRedemptionRoutes(api)
 	c.RegisterReportRoutes(api)
+	c.RegisterShiftRoutes(api)
+	c.RegisterAdminRoutes(api)
 	c.RegisterCommonRoutes(c.Router)
 }
@@ -0,0 +1,12 @@
+package route
+
+import "github.com/gin-gonic/gin"
+
+func (c *RouteConfig) RegisterShiftRoutes(rg *gin.RouterGroup) {
+	shifts := rg.Group("/shifts")
+
+	shifts.POST("", c.ShiftController.Open)
+	shifts.GET("/active", c.ShiftController.Active)
+	shifts.POST("/:id/close", c.ShiftController.Close)
+	shifts.GET("/:id/report", c.ShiftController.Report)
+}
@@ -7,4 +7,6 @@ func (c *RouteConfig) RegisterTransactionRoutes(rg *gin.RouterGroup) {
 
 	transactions.POST("", c.TransactionController.Create)
 	transactions.GET("", c.TransactionController.List)
+	transactions.GET("/:id", c.TransactionController.Detail)
+	transactions.GET("/:id/receipt", c.TransactionController.Receipt)
 }
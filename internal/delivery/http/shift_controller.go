@@ -0,0 +1,129 @@
+package http
+
+import (
+	"net/http"
+	"strings"
+
+	"snack-store-api/internal/messages"
+	"snack-store-api/internal/model"
+	"snack-store-api/internal/usecase"
+	"snack-store-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+)
+
+type ShiftController struct {
+	Log      *logrus.Logger
+	UseCase  *usecase.ShiftUseCase
+	Validate *validator.Validate
+}
+
+func NewShiftController(
+	useCase *usecase.ShiftUseCase,
+	logger *logrus.Logger,
+	validate *validator.Validate,
+) *ShiftController {
+	return &ShiftController{
+		Log:      logger,
+		UseCase:  useCase,
+		Validate: validate,
+	}
+}
+
+func (c *ShiftController) Open(ctx *gin.Context) {
+	request := new(model.OpenShiftRequest)
+	if err := ctx.ShouldBindJSON(request); err != nil {
+		c.Log.Warnf("Failed to parse request body : %+v", err)
+		utils.HandleHTTPError(ctx, utils.Error(messages.FailedDataFromBody, http.StatusBadRequest, err))
+		return
+	}
+
+	request.CashierName = strings.TrimSpace(request.CashierName)
+	request.OpenedAt = strings.TrimSpace(request.OpenedAt)
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Validation failed : %+v", err)
+		message := utils.TranslateValidationError(c.Validate, err)
+		utils.HandleHTTPError(ctx, utils.Error(message, http.StatusBadRequest, err))
+		return
+	}
+
+	response, err := c.UseCase.Open(ctx.Request.Context(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to open shift : %+v", err)
+		utils.HandleHTTPError(ctx, err)
+		return
+	}
+
+	res := utils.SuccessResponse(messages.ShiftOpened, response)
+	ctx.JSON(http.StatusCreated, res)
+}
+
+func (c *ShiftController) Active(ctx *gin.Context) {
+	request := &model.GetActiveShiftRequest{
+		CashierName: ctx.Query("cashier_name"),
+	}
+
+	response, err := c.UseCase.Active(ctx.Request.Context(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to get active shift : %+v", err)
+		utils.HandleHTTPError(ctx, err)
+		return
+	}
+
+	res := utils.SuccessResponse(messages.ShiftFetched, response)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *ShiftController) Close(ctx *gin.Context) {
+	request := new(model.CloseShiftRequest)
+	if err := ctx.ShouldBindJSON(request); err != nil {
+		c.Log.Warnf("Failed to parse request body : %+v", err)
+		utils.HandleHTTPError(ctx, utils.Error(messages.FailedDataFromBody, http.StatusBadRequest, err))
+		return
+	}
+
+	request.ID = strings.TrimSpace(ctx.Param("id"))
+	request.ClosedAt = strings.TrimSpace(request.ClosedAt)
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Validation failed : %+v", err)
+		message := utils.TranslateValidationError(c.Validate, err)
+		utils.HandleHTTPError(ctx, utils.Error(message, http.StatusBadRequest, err))
+		return
+	}
+
+	response, err := c.UseCase.Close(ctx.Request.Context(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to close shift : %+v", err)
+		utils.HandleHTTPError(ctx, err)
+		return
+	}
+
+	res := utils.SuccessResponse(messages.ShiftClosed, response)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *ShiftController) Report(ctx *gin.Context) {
+	request := new(model.GetShiftRequest)
+	request.ID = strings.TrimSpace(ctx.Param("id"))
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Validation failed : %+v", err)
+		message := utils.TranslateValidationError(c.Validate, err)
+		utils.HandleHTTPError(ctx, utils.Error(message, http.StatusBadRequest, err))
+		return
+	}
+
+	response, err := c.UseCase.Report(ctx.Request.Context(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to get shift report : %+v", err)
+		utils.HandleHTTPError(ctx, err)
+		return
+	}
+
+	res := utils.SuccessResponse(messages.ReportFetched, response)
+	ctx.JSON(http.StatusOK, res)
+}
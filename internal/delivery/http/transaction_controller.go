@@ -1,12 +1,15 @@
 package http
 
 import (
+	"fmt"
 	"net/http"
 	"strings"
 
 	"snack-store-api/internal/constants"
+	"snack-store-api/internal/export"
 	"snack-store-api/internal/messages"
 	"snack-store-api/internal/model"
+	"snack-store-api/internal/receipt"
 	"snack-store-api/internal/usecase"
 	"snack-store-api/internal/utils"
 
@@ -16,20 +19,23 @@ import (
 )
 
 type TransactionController struct {
-	Log      *logrus.Logger
-	UseCase  *usecase.TransactionUseCase
-	Validate *validator.Validate
+	Log             *logrus.Logger
+	UseCase         *usecase.TransactionUseCase
+	Validate        *validator.Validate
+	ReceiptRenderer *receipt.Renderer
 }
 
 func NewTransactionController(
 	useCase *usecase.TransactionUseCase,
 	logger *logrus.Logger,
 	validate *validator.Validate,
+	receiptRenderer *receipt.Renderer,
 ) *TransactionController {
 	return &TransactionController{
-		Log:      logger,
-		UseCase:  useCase,
-		Validate: validate,
+		Log:             logger,
+		UseCase:         useCase,
+		Validate:        validate,
+		ReceiptRenderer: receiptRenderer,
 	}
 }
 
@@ -67,6 +73,29 @@ func (c *TransactionController) List(ctx *gin.Context) {
 	request := new(model.GetTransactionRequest)
 	request.Start = strings.TrimSpace(ctx.Query("start"))
 	request.End = strings.TrimSpace(ctx.Query("end"))
+	request.CustomerName = strings.TrimSpace(ctx.Query("customer"))
+	request.ProductID = strings.TrimSpace(ctx.Query("product_id"))
+	request.Flavor = strings.TrimSpace(ctx.Query("flavor"))
+	request.Size = strings.TrimSpace(ctx.Query("size"))
+	request.Sort = strings.ToLower(strings.TrimSpace(ctx.Query("sort")))
+
+	minTotal, err := utils.ParseOptionalInt(ctx.Query("min_total"))
+	if err != nil {
+		c.Log.Warnf("Failed to parse min_total : %+v", err)
+		utils.HandleHTTPError(ctx, utils.Error(messages.FailedInputFormat, http.StatusBadRequest, err))
+		return
+	}
+
+	maxTotal, err := utils.ParseOptionalInt(ctx.Query("max_total"))
+	if err != nil {
+		c.Log.Warnf("Failed to parse max_total : %+v", err)
+		utils.HandleHTTPError(ctx, utils.Error(messages.FailedInputFormat, http.StatusBadRequest, err))
+		return
+	}
+
+	request.MinTotal = minTotal
+	request.MaxTotal = maxTotal
+
 	page, pageSize, err := utils.ParsePagination(
 		ctx.Query("page"),
 		ctx.Query("page_size"),
@@ -82,6 +111,12 @@ func (c *TransactionController) List(ctx *gin.Context) {
 	request.Page = page
 	request.PageSize = pageSize
 
+	cursor, cursorMode := ctx.GetQuery("cursor")
+	request.Cursor = cursor
+	request.WithCount = strings.EqualFold(strings.TrimSpace(ctx.Query("with_count")), "true")
+	request.Format = export.NegotiateFormat(ctx.Query("format"), ctx.GetHeader("Accept"))
+	request.Locale = strings.ToLower(strings.TrimSpace(ctx.DefaultQuery("locale", export.LocaleID)))
+
 	if err := c.Validate.Struct(request); err != nil {
 		c.Log.Warnf("Validation failed : %+v", err)
 		message := utils.TranslateValidationError(c.Validate, err)
@@ -89,6 +124,31 @@ func (c *TransactionController) List(ctx *gin.Context) {
 		return
 	}
 
+	if request.Format != "" {
+		filename := fmt.Sprintf("transactions-%s_%s.%s", request.Start, request.End, request.Format)
+		out := newDownloadWriter(ctx, export.ContentType(request.Format), filename)
+		if err := c.UseCase.Export(ctx.Request.Context(), request, request.Format, request.Locale, out); err != nil {
+			c.Log.Warnf("Failed to export transactions : %+v", err)
+			if !out.Started() {
+				utils.HandleHTTPError(ctx, err)
+			}
+		}
+		return
+	}
+
+	if cursorMode {
+		response, cursor, err := c.UseCase.ListByCursor(ctx.Request.Context(), request)
+		if err != nil {
+			c.Log.Warnf("Failed to get transactions : %+v", err)
+			utils.HandleHTTPError(ctx, err)
+			return
+		}
+
+		res := utils.SuccessWithCursorResponse(messages.TransactionsFetched, response, cursor)
+		ctx.JSON(http.StatusOK, res)
+		return
+	}
+
 	response, paging, err := c.UseCase.List(ctx.Request.Context(), request)
 	if err != nil {
 		c.Log.Warnf("Failed to get transactions : %+v", err)
@@ -99,3 +159,61 @@ func (c *TransactionController) List(ctx *gin.Context) {
 	res := utils.SuccessWithPaginationResponse(messages.TransactionsFetched, response, paging)
 	ctx.JSON(http.StatusOK, res)
 }
+
+func (c *TransactionController) Detail(ctx *gin.Context) {
+	request := new(model.GetTransactionDetailRequest)
+	request.ID = strings.TrimSpace(ctx.Param("id"))
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Validation failed : %+v", err)
+		message := utils.TranslateValidationError(c.Validate, err)
+		utils.HandleHTTPError(ctx, utils.Error(message, http.StatusBadRequest, err))
+		return
+	}
+
+	response, err := c.UseCase.Detail(ctx.Request.Context(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to get transaction : %+v", err)
+		utils.HandleHTTPError(ctx, err)
+		return
+	}
+
+	res := utils.SuccessResponse(messages.TransactionFetched, response)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *TransactionController) Receipt(ctx *gin.Context) {
+	request := new(model.GetTransactionReceiptRequest)
+	request.ID = strings.TrimSpace(ctx.Param("id"))
+	request.Format = strings.ToLower(strings.TrimSpace(ctx.DefaultQuery("format", receipt.FormatText)))
+	request.Download = strings.EqualFold(strings.TrimSpace(ctx.Query("download")), "true")
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Validation failed : %+v", err)
+		message := utils.TranslateValidationError(c.Validate, err)
+		utils.HandleHTTPError(ctx, utils.Error(message, http.StatusBadRequest, err))
+		return
+	}
+
+	response, err := c.UseCase.Receipt(ctx.Request.Context(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to get receipt : %+v", err)
+		utils.HandleHTTPError(ctx, err)
+		return
+	}
+
+	body, contentType, err := c.ReceiptRenderer.Render(request.Format, response)
+	if err != nil {
+		c.Log.Warnf("Failed to render receipt : %+v", err)
+		utils.HandleHTTPError(ctx, utils.Error(messages.ErrRenderReceipt, http.StatusInternalServerError, err))
+		return
+	}
+
+	disposition := "inline"
+	if request.Download || request.Format == receipt.FormatPDF {
+		disposition = "attachment"
+	}
+	filename := fmt.Sprintf("receipt-%s.%s", response.ReceiptNo, receipt.FileExtension(request.Format))
+	ctx.Header("Content-Disposition", fmt.Sprintf("%s; filename=%q", disposition, filename))
+	ctx.Data(http.StatusOK, contentType, body)
+}
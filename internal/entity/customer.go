@@ -8,11 +8,14 @@ import (
 )
 
 type Customer struct {
-	ID        uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
-	Name      string    `gorm:"not null;check:length(btrim(name)) > 0"`
-	Points    int       `gorm:"not null;default:0;check:points >= 0"`
-	CreatedAt time.Time `gorm:"not null;default:now()"`
-	UpdatedAt time.Time `gorm:"not null;default:now()"`
+	ID     uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey;index:customers_created_at_id_idx,priority:2"`
+	Name   string    `gorm:"not null;check:length(btrim(name)) > 0"`
+	Points int       `gorm:"not null;default:0;check:points >= 0"`
+	// OpeningPoints is the balance carried over from before the transaction
+	// and redemption ledger; nil when it is not known.
+	OpeningPoints *int      `gorm:"check:opening_points >= 0"`
+	CreatedAt     time.Time `gorm:"not null;default:now();index:customers_created_at_id_idx,priority:1"`
+	UpdatedAt     time.Time `gorm:"not null;default:now()"`
 }
 
 func (u *Customer) TableName() string {
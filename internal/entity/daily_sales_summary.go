@@ -0,0 +1,38 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DailySalesSummary pre-aggregates transactions per store-local day and
+// product so reports over whole days do not have to scan the raw transactions
+// table.
+type DailySalesSummary struct {
+	SalesDate        time.Time `gorm:"column:sales_date;type:date;primaryKey"`
+	ProductID        uuid.UUID `gorm:"type:uuid;primaryKey;index:daily_sales_summary_product_id_idx"`
+	Product          Product   `gorm:"constraint:OnUpdate:RESTRICT,OnDelete:RESTRICT;"`
+	Qty              int64     `gorm:"not null;default:0;check:qty >= 0"`
+	Revenue          int64     `gorm:"not null;default:0;check:revenue >= 0"`
+	TransactionCount int64     `gorm:"column:transaction_count;not null;default:0;check:transaction_count >= 0"`
+	UpdatedAt        time.Time `gorm:"not null;default:now()"`
+}
+
+func (d *DailySalesSummary) TableName() string {
+	return "daily_sales_summary"
+}
+
+// SalesDate returns midnight of the day, in the store location, that a
+// transaction is summarised under.
+func SalesDate(transactionAt time.Time, location *time.Location) time.Time {
+	local := transactionAt.In(location)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, location)
+}
+
+// IsWholeDayRange reports whether [start, end) covers whole days of the store
+// location only, which is the precondition for answering it from the daily
+// summary.
+func IsWholeDayRange(start, end time.Time, location *time.Location) bool {
+	return end.After(start) && SalesDate(start, location).Equal(start) && SalesDate(end, location).Equal(end)
+}
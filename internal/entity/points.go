@@ -1,5 +1,51 @@
 package entity
 
+import "math"
+
 func PointsEarned(totalPrice int) int {
 	return totalPrice / 1000
 }
+
+// PointLiability values outstanding points at the rate observed in past
+// redemptions: redeemedValue rupiah handed out for pointsSpent points. The
+// point value is rounded to two decimals; ok is false when no points have
+// been spent yet, since there is no observed rate to apply.
+func PointLiability(outstanding, pointsSpent, redeemedValue int64) (pointValue float64, liability int64, ok bool) {
+	if pointsSpent <= 0 {
+		return 0, 0, false
+	}
+
+	rate := float64(redeemedValue) / float64(pointsSpent)
+	liability = int64(math.Round(float64(outstanding) * rate))
+	return math.Round(rate*100) / 100, liability, true
+}
+
+// Outcomes of ReconcilePoints.
+const (
+	PointsMatch       = "match"
+	PointsCorrectable = "correctable"
+	PointsNoOpening   = "no_opening"
+	PointsNegative    = "negative"
+)
+
+// ReconcilePoints compares a stored balance with the ledger: the opening
+// balance carried over from before the ledger plus points earned minus points
+// spent. Only a correctable balance may be overwritten with expected. Without
+// an opening balance expected is earned minus spent alone, and a negative
+// expected balance means the ledger itself is inconsistent; both need a
+// person to look at them.
+func ReconcilePoints(stored int, opening *int, earned, spent int64) (expected int64, status string) {
+	if opening == nil {
+		return earned - spent, PointsNoOpening
+	}
+
+	expected = int64(*opening) + earned - spent
+	switch {
+	case expected < 0:
+		return expected, PointsNegative
+	case expected == int64(stored):
+		return expected, PointsMatch
+	default:
+		return expected, PointsCorrectable
+	}
+}
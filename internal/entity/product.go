@@ -14,6 +14,11 @@ const (
 	SizeLarge  = "Large"
 )
 
+var Sizes = []string{SizeSmall, SizeMedium, SizeLarge}
+
+// Flavors mirrors the products flavor check constraint.
+var Flavors = []string{"Jagung Bakar", "Rumput Laut", "Original", "Jagung Manis", "Keju Asin", "Keju Manis", "Pedas"}
+
 func PointsCost(size string) int {
 	switch strings.TrimSpace(size) {
 	case SizeSmall:
@@ -34,6 +39,7 @@ type Product struct {
 	Flavor           string    `gorm:"not null;check:flavor IN ('Jagung Bakar','Rumput Laut','Original','Jagung Manis','Keju Asin','Keju Manis','Pedas');index:products_flavor_idx"`
 	Size             string    `gorm:"type:varchar(10);not null;check:size IN ('Small','Medium','Large');index:products_size_idx"`
 	Price            int       `gorm:"not null;check:price >= 0"`
+	CostPrice        *int      `gorm:"column:cost_price;check:cost_price >= 0"`
 	StockQty         int       `gorm:"column:stock_qty;not null;check:stock_qty >= 0"`
 	ManufacturedDate time.Time `gorm:"type:date;not null;index:products_manufactured_date_idx"`
 	CreatedAt        time.Time `gorm:"not null;default:now()"`
@@ -51,3 +57,29 @@ func (p *Product) BeforeCreate(_ *gorm.DB) (err error) {
 
 	return
 }
+
+// Stock age buckets, by days since the manufactured date.
+const (
+	StockAge0To30   = "0-30"
+	StockAge31To60  = "31-60"
+	StockAge61To90  = "61-90"
+	StockAge91To180 = "91-180"
+	StockAgeOver180 = ">180"
+)
+
+var StockAgeBuckets = []string{StockAge0To30, StockAge31To60, StockAge61To90, StockAge91To180, StockAgeOver180}
+
+func StockAgeBucket(ageDays int) string {
+	switch {
+	case ageDays <= 30:
+		return StockAge0To30
+	case ageDays <= 60:
+		return StockAge31To60
+	case ageDays <= 90:
+		return StockAge61To90
+	case ageDays <= 180:
+		return StockAge91To180
+	default:
+		return StockAgeOver180
+	}
+}
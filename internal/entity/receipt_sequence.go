@@ -0,0 +1,20 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+type ReceiptSequence struct {
+	ReceiptDate time.Time `gorm:"column:receipt_date;type:date;primaryKey"`
+	LastNumber  int       `gorm:"column:last_number;not null;default:0;check:last_number >= 0"`
+	UpdatedAt   time.Time `gorm:"not null;default:now()"`
+}
+
+func (r *ReceiptSequence) TableName() string {
+	return "receipt_sequences"
+}
+
+func FormatReceiptNo(receiptDate time.Time, sequence int) string {
+	return fmt.Sprintf("%s-%04d", receiptDate.Format("20060102"), sequence)
+}
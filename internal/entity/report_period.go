@@ -0,0 +1,37 @@
+package entity
+
+import "time"
+
+const (
+	CompareNone     = ""
+	ComparePrevious = "previous"
+	CompareYoY      = "yoy"
+)
+
+// ComparisonPeriod returns the period to compare [start, end) against, with end
+// exclusive. Ranges covering whole calendar months shift by months so March is
+// compared with February rather than the 31 days before it; other ranges shift
+// by their length in days. YoY shifts the same range back one year, with
+// Feb 29 landing on Feb 28 rather than rolling over into March.
+func ComparisonPeriod(start, end time.Time, mode string) (time.Time, time.Time) {
+	wholeMonths := start.Day() == 1 && end.Day() == 1
+	months := (end.Year()-start.Year())*12 + int(end.Month()) - int(start.Month())
+
+	switch {
+	case mode == CompareYoY:
+		return previousYear(start), previousYear(end)
+	case wholeMonths && months > 0:
+		return start.AddDate(0, -months, 0), start
+	default:
+		return start.Add(-end.Sub(start)), start
+	}
+}
+
+// previousYear moves t back one year, clamping Feb 29 to Feb 28.
+func previousYear(t time.Time) time.Time {
+	shifted := t.AddDate(-1, 0, 0)
+	if shifted.Month() != t.Month() {
+		shifted = shifted.AddDate(0, 0, -shifted.Day())
+	}
+	return shifted
+}
@@ -0,0 +1,42 @@
+package entity
+
+import (
+	"time"
+
+	"snack-store-api/internal/constants"
+)
+
+// SeriesBucket truncates a wall-clock time to the start of its day, ISO week
+// (Monday) or month bucket, like Postgres date_trunc. The result keeps the
+// location of t.
+func SeriesBucket(t time.Time, granularity string) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	switch granularity {
+	case constants.GranularityWeek:
+		offset := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, -offset)
+	case constants.GranularityMonth:
+		return day.AddDate(0, 0, 1-day.Day())
+	default:
+		return day
+	}
+}
+
+// SeriesBuckets lists every bucket start covering the calendar dates
+// [start, end), so a series can be zero-filled where nothing happened.
+func SeriesBuckets(start, end time.Time, granularity string) []time.Time {
+	var buckets []time.Time
+	for current := SeriesBucket(start, granularity); current.Before(end); {
+		buckets = append(buckets, current)
+		switch granularity {
+		case constants.GranularityWeek:
+			current = current.AddDate(0, 0, 7)
+		case constants.GranularityMonth:
+			current = current.AddDate(0, 1, 0)
+		default:
+			current = current.AddDate(0, 0, 1)
+		}
+	}
+
+	return buckets
+}
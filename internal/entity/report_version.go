@@ -0,0 +1,53 @@
+package entity
+
+import (
+	"time"
+
+	"snack-store-api/internal/constants"
+)
+
+// ReportVersionBuckets lists the version buckets a cached report over the
+// range [start, end) of store-local midnights depends on. Whole calendar years
+// map to a year bucket, whole months to a month bucket and the remaining days
+// to day buckets. A range that would still need more than
+// MaxReportVersionBuckets falls back to the single all bucket.
+func ReportVersionBuckets(start, end time.Time) []string {
+	var buckets []string
+	for current := start; current.Before(end); {
+		if len(buckets) == constants.MaxReportVersionBuckets {
+			return []string{constants.ReportVersionAllBucket}
+		}
+
+		nextYear := current.AddDate(1, 0, 0)
+		if current.YearDay() == 1 && !nextYear.After(end) {
+			buckets = append(buckets, current.Format(constants.YearLayout))
+			current = nextYear
+			continue
+		}
+
+		nextMonth := current.AddDate(0, 1, 0)
+		if current.Day() == 1 && !nextMonth.After(end) {
+			buckets = append(buckets, current.Format(constants.MonthLayout))
+			current = nextMonth
+			continue
+		}
+
+		buckets = append(buckets, current.Format(constants.DateLayout))
+		current = current.AddDate(0, 0, 1)
+	}
+
+	return buckets
+}
+
+// SaleVersionBuckets lists the buckets to retire for a sale at transactionAt:
+// its store-local day, month and year plus the all bucket, covering every
+// shape ReportVersionBuckets produces.
+func SaleVersionBuckets(transactionAt time.Time, location *time.Location) []string {
+	day := SalesDate(transactionAt, location)
+	return []string{
+		day.Format(constants.DateLayout),
+		day.Format(constants.MonthLayout),
+		day.Format(constants.YearLayout),
+		constants.ReportVersionAllBucket,
+	}
+}
@@ -0,0 +1,119 @@
+package entity
+
+import (
+	"sort"
+	"time"
+)
+
+// RFM segments assigned from recency, frequency and monetary scores (1-5).
+const (
+	SegmentChampions = "champions"
+	SegmentNew       = "new"
+	SegmentAtRisk    = "at_risk"
+	SegmentLost      = "lost"
+	SegmentRegular   = "regular"
+)
+
+// RFMNewCustomerDays is how recent a first purchase must be for a customer
+// that is not yet a champion to be segmented as new.
+const RFMNewCustomerDays = 30
+
+// rfmScoreLevels is the number of score levels per dimension.
+const rfmScoreLevels = 5
+
+type RFMStats struct {
+	FirstPurchaseAt time.Time
+	LastPurchaseAt  time.Time
+	Frequency       int
+	Monetary        int64
+}
+
+type RFMScore struct {
+	RecencyDays    int
+	RecencyScore   int
+	FrequencyScore int
+	MonetaryScore  int
+	Segment        string
+}
+
+// RFMQuintile scores a value from how many of the total population have a
+// value at or below it (ties included): ceil(5 * atOrBelow / total). Equal
+// values share a score and the best value always scores 5, so a single
+// customer or an all-tied population lands at the top rather than the bottom.
+func RFMQuintile(atOrBelow, total int) int {
+	if total <= 0 || atOrBelow <= 0 {
+		return 1
+	}
+
+	return (rfmScoreLevels*atOrBelow + total - 1) / total
+}
+
+// RFMSegment maps scores to a segment, evaluating the rules in order.
+func RFMSegment(recency, frequency, monetary int, firstPurchaseAt, asOf time.Time) string {
+	switch {
+	case recency >= 4 && frequency >= 4:
+		return SegmentChampions
+	case !firstPurchaseAt.Before(asOf.AddDate(0, 0, -RFMNewCustomerDays)):
+		return SegmentNew
+	case recency <= 2 && (frequency >= 3 || monetary >= 3):
+		return SegmentAtRisk
+	case recency == 1:
+		return SegmentLost
+	default:
+		return SegmentRegular
+	}
+}
+
+// ScoreRFM scores every customer against the whole population as of asOf and
+// returns the scores in the order of stats.
+func ScoreRFM(stats []RFMStats, asOf time.Time) []RFMScore {
+	recency := cumulativeCounts(len(stats), func(i, j int) bool {
+		return stats[i].LastPurchaseAt.Before(stats[j].LastPurchaseAt)
+	})
+	frequency := cumulativeCounts(len(stats), func(i, j int) bool {
+		return stats[i].Frequency < stats[j].Frequency
+	})
+	monetary := cumulativeCounts(len(stats), func(i, j int) bool {
+		return stats[i].Monetary < stats[j].Monetary
+	})
+
+	scores := make([]RFMScore, len(stats))
+	for i, stat := range stats {
+		score := RFMScore{
+			RecencyDays:    max(0, int(asOf.Sub(stat.LastPurchaseAt)/(24*time.Hour))),
+			RecencyScore:   RFMQuintile(recency[i], len(stats)),
+			FrequencyScore: RFMQuintile(frequency[i], len(stats)),
+			MonetaryScore:  RFMQuintile(monetary[i], len(stats)),
+		}
+		score.Segment = RFMSegment(score.RecencyScore, score.FrequencyScore, score.MonetaryScore, stat.FirstPurchaseAt, asOf)
+		scores[i] = score
+	}
+
+	return scores
+}
+
+// cumulativeCounts returns, for each of n items, how many items are less than
+// or equal to it under less.
+func cumulativeCounts(n int, less func(i, j int) bool) []int {
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return less(order[a], order[b])
+	})
+
+	counts := make([]int, n)
+	for end := 0; end < n; {
+		next := end + 1
+		for next < n && !less(order[end], order[next]) {
+			next++
+		}
+		for _, index := range order[end:next] {
+			counts[index] = next
+		}
+		end = next
+	}
+
+	return counts
+}
@@ -0,0 +1,53 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	ShiftStatusOpen   = "open"
+	ShiftStatusClosed = "closed"
+)
+
+type Shift struct {
+	ID           uuid.UUID  `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	CashierName  string     `gorm:"column:cashier_name;not null;check:length(btrim(cashier_name)) > 0;index:shifts_cashier_name_idx;uniqueIndex:shifts_open_cashier_key,where:status = 'open'"`
+	Status       string     `gorm:"type:varchar(10);not null;default:'open';check:status IN ('open','closed')"`
+	OpeningFloat int        `gorm:"column:opening_float;not null;check:opening_float >= 0"`
+	ExpectedCash *int       `gorm:"column:expected_cash"`
+	CountedCash  *int       `gorm:"column:counted_cash;check:counted_cash >= 0"`
+	Notes        string     `gorm:"not null;default:''"`
+	OpenedAt     time.Time  `gorm:"column:opened_at;not null;index:shifts_opened_at_idx"`
+	ClosedAt     *time.Time `gorm:"column:closed_at"`
+	CreatedAt    time.Time  `gorm:"not null;default:now()"`
+	UpdatedAt    time.Time  `gorm:"not null;default:now()"`
+}
+
+func (s *Shift) TableName() string {
+	return "shifts"
+}
+
+func (s *Shift) BeforeCreate(_ *gorm.DB) (err error) {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+
+	return
+}
+
+// ReconcileShift computes the cash expected in the drawer at close, the opening
+// float plus the shift's sales, and the counted minus expected difference when
+// the drawer has been counted. Sales carry no payment method, so every sale is
+// assumed to be paid in cash.
+func ReconcileShift(openingFloat int, totalSales int64, countedCash *int) (int64, *int64) {
+	expected := int64(openingFloat) + totalSales
+	if countedCash == nil {
+		return expected, nil
+	}
+
+	difference := int64(*countedCash) - expected
+	return expected, &difference
+}
@@ -0,0 +1,57 @@
+package entity
+
+import "strings"
+
+const basisPointsPerUnit = 10000
+
+type TaxAmount struct {
+	Net   int
+	Tax   int
+	Gross int
+}
+
+type TaxPolicy struct {
+	DefaultRateBps int
+	RatesBps       map[string]int
+	PriceInclusive bool
+}
+
+func (p *TaxPolicy) RateFor(productType string) int {
+	if p == nil {
+		return 0
+	}
+
+	if rate, ok := p.RatesBps[NormalizeProductType(productType)]; ok {
+		return rate
+	}
+
+	return p.DefaultRateBps
+}
+
+func (p *TaxPolicy) Calculate(productType string, amount int) (TaxAmount, int) {
+	rate := p.RateFor(productType)
+	inclusive := p != nil && p.PriceInclusive
+	return CalculateTax(amount, rate, inclusive), rate
+}
+
+func CalculateTax(amount int, rateBps int, inclusive bool) TaxAmount {
+	if amount <= 0 || rateBps <= 0 {
+		return TaxAmount{Net: amount, Tax: 0, Gross: amount}
+	}
+
+	if inclusive {
+		net := roundDiv(amount*basisPointsPerUnit, basisPointsPerUnit+rateBps)
+		return TaxAmount{Net: net, Tax: amount - net, Gross: amount}
+	}
+
+	tax := roundDiv(amount*rateBps, basisPointsPerUnit)
+	return TaxAmount{Net: amount, Tax: tax, Gross: amount + tax}
+}
+
+func NormalizeProductType(productType string) string {
+	return strings.ToLower(strings.TrimSpace(productType))
+}
+
+func roundDiv(numerator, denominator int) int {
+	return (numerator + denominator/2) / denominator
+}
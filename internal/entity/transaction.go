@@ -8,17 +8,24 @@ import (
 )
 
 type Transaction struct {
-	ID            uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
-	CustomerID    uuid.UUID `gorm:"type:uuid;not null;index:transactions_customer_id_idx"`
-	Customer      Customer  `gorm:"constraint:OnUpdate:RESTRICT,OnDelete:RESTRICT;"`
-	ProductID     uuid.UUID `gorm:"type:uuid;not null;index:transactions_product_id_idx;index:transactions_product_time_idx,priority:1"`
-	Product       Product   `gorm:"constraint:OnUpdate:RESTRICT,OnDelete:RESTRICT;"`
-	Qty           int       `gorm:"not null;check:qty > 0"`
-	UnitPrice     int       `gorm:"column:unit_price;not null;check:unit_price >= 0"`
-	TotalPrice    int       `gorm:"column:total_price;not null;check:total_price >= 0"`
-	PointsEarned  int       `gorm:"column:points_earned;not null;check:points_earned >= 0"`
-	TransactionAt time.Time `gorm:"column:transaction_at;not null;index:transactions_transaction_at_idx;index:transactions_product_time_idx,priority:2"`
-	CreatedAt     time.Time `gorm:"not null;default:now()"`
+	ID            uuid.UUID  `gorm:"type:uuid;default:gen_random_uuid();primaryKey;index:transactions_transaction_at_id_idx,priority:2"`
+	ReceiptNo     *string    `gorm:"column:receipt_no;type:varchar(20);uniqueIndex:transactions_receipt_no_key"`
+	CustomerID    uuid.UUID  `gorm:"type:uuid;not null;index:transactions_customer_id_idx"`
+	Customer      Customer   `gorm:"constraint:OnUpdate:RESTRICT,OnDelete:RESTRICT;" json:"-"`
+	ProductID     uuid.UUID  `gorm:"type:uuid;not null;index:transactions_product_id_idx;index:transactions_product_time_idx,priority:1"`
+	Product       Product    `gorm:"constraint:OnUpdate:RESTRICT,OnDelete:RESTRICT;" json:"-"`
+	ShiftID       *uuid.UUID `gorm:"type:uuid;index:transactions_shift_id_idx"`
+	Shift         *Shift     `gorm:"constraint:OnUpdate:RESTRICT,OnDelete:RESTRICT;" json:"-"`
+	Qty           int        `gorm:"not null;check:qty > 0"`
+	UnitPrice     int        `gorm:"column:unit_price;not null;check:unit_price >= 0"`
+	TotalPrice    int        `gorm:"column:total_price;not null;check:total_price >= 0"`
+	NetAmount     int        `gorm:"column:net_amount;not null;default:0;check:net_amount >= 0"`
+	TaxAmount     int        `gorm:"column:tax_amount;not null;default:0;check:tax_amount >= 0"`
+	TaxRateBps    int        `gorm:"column:tax_rate_bps;not null;default:0;check:tax_rate_bps >= 0"`
+	PointsEarned  int        `gorm:"column:points_earned;not null;check:points_earned >= 0"`
+	PointsBalance *int       `gorm:"column:points_balance;check:points_balance >= 0"`
+	TransactionAt time.Time  `gorm:"column:transaction_at;not null;index:transactions_transaction_at_idx;index:transactions_product_time_idx,priority:2;index:transactions_transaction_at_id_idx,priority:1"`
+	CreatedAt     time.Time  `gorm:"not null;default:now()"`
 }
 
 func (t *Transaction) TableName() string {
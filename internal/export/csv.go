@@ -0,0 +1,155 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const csvDateTimeLayout = "2006-01-02 15:04:05"
+
+// utf8BOM makes spreadsheet applications detect UTF-8 instead of the system codepage.
+const utf8BOM = "\xef\xbb\xbf"
+
+type CSVWriter struct {
+	writer   *csv.Writer
+	out      io.Writer
+	locale   string
+	location *time.Location
+	columns  []Column
+}
+
+// NewCSVWriter writes CSV tuned for spreadsheets opened in the given locale.
+// The Indonesian locale uses ';' as delimiter and '.' as thousands separator,
+// matching how a spreadsheet with Indonesian regional settings parses numbers.
+func NewCSVWriter(out io.Writer, locale string, location *time.Location) *CSVWriter {
+	if location == nil {
+		location = time.UTC
+	}
+
+	writer := csv.NewWriter(out)
+	if locale == LocaleID {
+		writer.Comma = ';'
+	}
+
+	return &CSVWriter{
+		writer:   writer,
+		out:      out,
+		locale:   locale,
+		location: location,
+	}
+}
+
+func (w *CSVWriter) ContentType() string { return contentTypeCSV }
+
+func (w *CSVWriter) Extension() string { return FormatCSV }
+
+func (w *CSVWriter) MultiSheet() bool { return false }
+
+func (w *CSVWriter) Sheet(_ string, columns []Column) error {
+	if w.columns != nil {
+		return ErrSingleSheet
+	}
+	w.columns = columns
+
+	if _, err := io.WriteString(w.out, utf8BOM); err != nil {
+		return err
+	}
+
+	titles := make([]string, len(columns))
+	for i, column := range columns {
+		titles[i] = column.Title
+	}
+
+	return w.writer.Write(titles)
+}
+
+func (w *CSVWriter) Row(values ...interface{}) error {
+	record := make([]string, len(values))
+	for i, value := range values {
+		kind := KindText
+		if i < len(w.columns) {
+			kind = w.columns[i].Kind
+		}
+		record[i] = w.format(kind, value)
+		if kind == KindText {
+			record[i] = EscapeFormula(record[i])
+		}
+	}
+
+	return w.writer.Write(record)
+}
+
+func (w *CSVWriter) Close() error {
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+// Abort does nothing: rows already reached out as they were written and
+// nothing else is held.
+func (w *CSVWriter) Abort() error { return nil }
+
+func (w *CSVWriter) format(kind Kind, value interface{}) string {
+	switch kind {
+	case KindInt:
+		if number, ok := intValue(value); ok {
+			return w.formatInt(number)
+		}
+		return ""
+	case KindDateTime:
+		if t, ok := timeValue(value); ok {
+			return t.In(w.location).Format(csvDateTimeLayout)
+		}
+		return ""
+	}
+
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case *string:
+		if v == nil {
+			return ""
+		}
+		return *v
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// EscapeFormula prefixes text that a spreadsheet would evaluate as a formula
+// with a quote, so names and notes typed by users cannot run as formulas when
+// the export is opened. XLSX cells are typed and need no escaping.
+func EscapeFormula(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+func (w *CSVWriter) formatInt(number int64) string {
+	if w.locale != LocaleID {
+		return strconv.FormatInt(number, 10)
+	}
+
+	sign := ""
+	if number < 0 {
+		sign = "-"
+		number = -number
+	}
+
+	digits := strconv.FormatInt(number, 10)
+	var b strings.Builder
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte('.')
+		}
+		b.WriteRune(d)
+	}
+
+	return sign + b.String()
+}
@@ -0,0 +1,139 @@
+package export
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"strings"
+	"time"
+)
+
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+)
+
+const (
+	LocaleID = "id"
+	LocaleEN = "en"
+)
+
+const (
+	contentTypeCSV  = "text/csv; charset=utf-8"
+	contentTypeXLSX = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+)
+
+// Kind tells a Writer how to format the values of a column.
+type Kind int
+
+const (
+	KindText Kind = iota
+	KindInt
+	KindDateTime
+)
+
+type Column struct {
+	Title string
+	Kind  Kind
+}
+
+var ErrSingleSheet = errors.New("format supports a single sheet only")
+
+// Writer writes tabular rows to a file format as they are produced, so callers
+// can stream straight from a database cursor.
+type Writer interface {
+	ContentType() string
+	Extension() string
+	// MultiSheet reports whether Sheet may be called more than once.
+	MultiSheet() bool
+	// Sheet starts a new table. Values passed to Row must follow its columns.
+	Sheet(name string, columns []Column) error
+	Row(values ...interface{}) error
+	// Close finishes the file and writes whatever is still buffered to out.
+	Close() error
+	// Abort releases the writer without writing anything further. It does
+	// nothing after Close, so callers can defer it and still check the error
+	// of Close.
+	Abort() error
+}
+
+// NegotiateFormat picks the export format from the explicit format query
+// value, falling back to the Accept header. It returns "" when neither asks for
+// a file export.
+func NegotiateFormat(format, accept string) string {
+	format = strings.ToLower(strings.TrimSpace(format))
+	if format != "" {
+		return format
+	}
+
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		switch mediaType {
+		case "text/csv":
+			return FormatCSV
+		case contentTypeXLSX:
+			return FormatXLSX
+		}
+	}
+
+	return ""
+}
+
+func timeValue(value interface{}) (time.Time, bool) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, true
+	case *time.Time:
+		if v == nil {
+			return time.Time{}, false
+		}
+		return *v, true
+	default:
+		return time.Time{}, false
+	}
+}
+
+func intValue(value interface{}) (int64, bool) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), true
+	case int64:
+		return v, true
+	case *int:
+		if v == nil {
+			return 0, false
+		}
+		return int64(*v), true
+	case *int64:
+		if v == nil {
+			return 0, false
+		}
+		return *v, true
+	default:
+		return 0, false
+	}
+}
+
+// NewWriter returns the Writer for format writing to out.
+func NewWriter(format string, out io.Writer, locale string, location *time.Location) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return NewCSVWriter(out, locale, location), nil
+	case FormatXLSX:
+		return NewXLSXWriter(out, location)
+	default:
+		return nil, fmt.Errorf("unsupported export format %q", format)
+	}
+}
+
+func ContentType(format string) string {
+	if format == FormatXLSX {
+		return contentTypeXLSX
+	}
+
+	return contentTypeCSV
+}
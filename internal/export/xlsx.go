@@ -0,0 +1,182 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	xlsxNumberFormat   = 3 // built-in "#,##0", shown with the reader's own separators
+	xlsxDateTimeFormat = "yyyy-mm-dd hh:mm:ss"
+)
+
+// XLSXWriter streams rows through excelize's StreamWriter, which spills to a
+// temporary file instead of keeping every row in memory. The workbook is
+// written to out on Close.
+type XLSXWriter struct {
+	out         io.Writer
+	location    *time.Location
+	file        *excelize.File
+	stream      *excelize.StreamWriter
+	columns     []Column
+	rowIndex    int
+	sheets      int
+	headerStyle int
+	numberStyle int
+	dateStyle   int
+	closed      bool
+}
+
+func NewXLSXWriter(out io.Writer, location *time.Location) (*XLSXWriter, error) {
+	if location == nil {
+		location = time.UTC
+	}
+
+	file := excelize.NewFile()
+	w := &XLSXWriter{out: out, location: location, file: file}
+
+	if err := w.newStyles(); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return w, nil
+}
+
+func (w *XLSXWriter) newStyles() error {
+	var err error
+	if w.headerStyle, err = w.file.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}}); err != nil {
+		return err
+	}
+	if w.numberStyle, err = w.file.NewStyle(&excelize.Style{NumFmt: xlsxNumberFormat}); err != nil {
+		return err
+	}
+	dateFormat := xlsxDateTimeFormat
+	w.dateStyle, err = w.file.NewStyle(&excelize.Style{CustomNumFmt: &dateFormat})
+	return err
+}
+
+func (w *XLSXWriter) ContentType() string { return contentTypeXLSX }
+
+func (w *XLSXWriter) Extension() string { return FormatXLSX }
+
+func (w *XLSXWriter) MultiSheet() bool { return true }
+
+func (w *XLSXWriter) Sheet(name string, columns []Column) error {
+	if err := w.flush(); err != nil {
+		return err
+	}
+
+	if w.sheets == 0 {
+		if err := w.file.SetSheetName("Sheet1", name); err != nil {
+			return err
+		}
+	} else if _, err := w.file.NewSheet(name); err != nil {
+		return err
+	}
+	w.sheets++
+
+	stream, err := w.file.NewStreamWriter(name)
+	if err != nil {
+		return err
+	}
+	w.stream = stream
+	w.columns = columns
+	w.rowIndex = 1
+
+	header := make([]interface{}, len(columns))
+	for i, column := range columns {
+		header[i] = excelize.Cell{StyleID: w.headerStyle, Value: column.Title}
+	}
+
+	return w.writeRow(header)
+}
+
+func (w *XLSXWriter) Row(values ...interface{}) error {
+	if w.stream == nil {
+		return fmt.Errorf("xlsx row written before sheet")
+	}
+
+	cells := make([]interface{}, len(values))
+	for i, value := range values {
+		kind := KindText
+		if i < len(w.columns) {
+			kind = w.columns[i].Kind
+		}
+		cells[i] = w.cell(kind, value)
+	}
+
+	return w.writeRow(cells)
+}
+
+func (w *XLSXWriter) Close() error {
+	w.closed = true
+	defer w.file.Close()
+
+	if err := w.flush(); err != nil {
+		return err
+	}
+
+	_, err := w.file.WriteTo(w.out)
+	return err
+}
+
+// Abort drops the workbook and the temporary files of its stream writers.
+func (w *XLSXWriter) Abort() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	return w.file.Close()
+}
+
+func (w *XLSXWriter) writeRow(cells []interface{}) error {
+	axis, err := excelize.CoordinatesToCellName(1, w.rowIndex)
+	if err != nil {
+		return err
+	}
+	w.rowIndex++
+
+	return w.stream.SetRow(axis, cells)
+}
+
+func (w *XLSXWriter) flush() error {
+	if w.stream == nil {
+		return nil
+	}
+
+	err := w.stream.Flush()
+	w.stream = nil
+	return err
+}
+
+func (w *XLSXWriter) cell(kind Kind, value interface{}) interface{} {
+	switch kind {
+	case KindInt:
+		if number, ok := intValue(value); ok {
+			return excelize.Cell{StyleID: w.numberStyle, Value: number}
+		}
+		return nil
+	case KindDateTime:
+		if t, ok := timeValue(value); ok {
+			// Spreadsheets have no timezone; store the store-local wall clock.
+			local := t.In(w.location)
+			wall := time.Date(local.Year(), local.Month(), local.Day(), local.Hour(), local.Minute(), local.Second(), 0, time.UTC)
+			return excelize.Cell{StyleID: w.dateStyle, Value: wall}
+		}
+		return nil
+	}
+
+	switch v := value.(type) {
+	case *string:
+		if v == nil {
+			return nil
+		}
+		return *v
+	default:
+		return v
+	}
+}
@@ -0,0 +1,551 @@
+package generator
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"math/rand/v2"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"snack-store-api/internal/entity"
+
+	"github.com/google/uuid"
+)
+
+// Options sizes a generated dataset. Start and End are inclusive calendar
+// days in the store location.
+type Options struct {
+	Customers    int
+	Products     int
+	Transactions int
+	Start        time.Time
+	End          time.Time
+	Seed         uint64
+	BatchSize    int
+}
+
+// Sink receives generated rows in insert order: customers and products
+// first, then transactions and redemptions in batches, then the receipt
+// sequences.
+type Sink interface {
+	Customers(rows []entity.Customer) error
+	Products(rows []entity.Product) error
+	Transactions(rows []entity.Transaction) error
+	Redemptions(rows []entity.Redemption) error
+	ReceiptSequences(rows []entity.ReceiptSequence) error
+}
+
+type Summary struct {
+	Customers    int
+	Products     int
+	Transactions int
+	Redemptions  int
+	Revenue      int64
+	// OpeningStock is each product's stock before the range: its closing
+	// stock_qty plus every unit sold or redeemed.
+	OpeningStock map[uuid.UUID]int
+}
+
+// redemptionChance is the probability that a customer who can afford the
+// cheapest redemption redeems right after a purchase.
+const redemptionChance = 0.08
+
+func (o Options) validate() error {
+	switch {
+	case o.Customers <= 0:
+		return errors.New("customers must be positive")
+	case o.Products <= 0:
+		return errors.New("products must be positive")
+	case o.Transactions < 0:
+		return errors.New("transactions must not be negative")
+	case o.BatchSize <= 0:
+		return errors.New("batch size must be positive")
+	case o.End.Before(o.Start):
+		return errors.New("end date is before start date")
+	}
+	return nil
+}
+
+// Generate builds a dataset and streams it to sink. The same options and
+// seed always produce the same rows.
+//
+// Customers and products must be inserted before the transactions that
+// reference them, but their created_at, points and stock are only known once
+// every transaction has been simulated. Generate therefore runs the
+// simulation twice from the same seed: the first pass settles the final
+// customer and product state, the second emits transactions and redemptions.
+func Generate(options Options, taxPolicy *entity.TaxPolicy, location *time.Location, sink Sink) (*Summary, error) {
+	if err := options.validate(); err != nil {
+		return nil, err
+	}
+	if location == nil {
+		location = time.UTC
+	}
+
+	plan := newSimulation(options, taxPolicy, location, nil)
+	if err := plan.run(); err != nil {
+		return nil, err
+	}
+	customers, products, openingStock := plan.finalState()
+
+	if err := sink.Customers(customers); err != nil {
+		return nil, err
+	}
+	if err := sink.Products(products); err != nil {
+		return nil, err
+	}
+
+	emit := newSimulation(options, taxPolicy, location, sink)
+	if err := emit.run(); err != nil {
+		return nil, err
+	}
+	if err := sink.ReceiptSequences(emit.receiptSequences()); err != nil {
+		return nil, err
+	}
+
+	return &Summary{
+		Customers:    len(customers),
+		Products:     len(products),
+		Transactions: emit.transactionCount,
+		Redemptions:  emit.redemptionCount,
+		Revenue:      emit.revenue,
+		OpeningStock: openingStock,
+	}, nil
+}
+
+type customerState struct {
+	entity.Customer
+	// lifetime is how long the customer keeps buying after the first visit.
+	lifetime   time.Duration
+	arrived    bool
+	lastActive time.Time
+}
+
+type productState struct {
+	entity.Product
+	weight float64
+	sold   int
+}
+
+type simulation struct {
+	options   Options
+	taxPolicy *entity.TaxPolicy
+	location  *time.Location
+	sink      Sink
+	rng       *rand.Rand
+
+	customers      []customerState
+	customerWeight []float64 // cumulative affinity in arrival order
+	arrived        int
+
+	products      []productState // sorted by manufactured date
+	productWeight []float64      // cumulative popularity
+
+	receipts map[time.Time]int
+
+	transactions     []entity.Transaction
+	redemptions      []entity.Redemption
+	transactionCount int
+	redemptionCount  int
+	revenue          int64
+}
+
+func newSimulation(options Options, taxPolicy *entity.TaxPolicy, location *time.Location, sink Sink) *simulation {
+	s := &simulation{
+		options:   options,
+		taxPolicy: taxPolicy,
+		location:  location,
+		sink:      sink,
+		rng:       rand.New(rand.NewPCG(options.Seed, 0x5eed)),
+		receipts:  map[time.Time]int{},
+	}
+	s.buildCustomers()
+	s.buildProducts()
+	return s
+}
+
+// newUUID draws a version 4 UUID from the simulation's random stream so IDs
+// are reproducible.
+func (s *simulation) newUUID() uuid.UUID {
+	var id uuid.UUID
+	binary.BigEndian.PutUint64(id[:8], s.rng.Uint64())
+	binary.BigEndian.PutUint64(id[8:], s.rng.Uint64())
+	id[6] = (id[6] & 0x0f) | 0x40
+	id[8] = (id[8] & 0x3f) | 0x80
+	return id
+}
+
+func (s *simulation) buildCustomers() {
+	s.customers = make([]customerState, s.options.Customers)
+	s.customerWeight = make([]float64, s.options.Customers)
+	seen := make(map[string]int, s.options.Customers)
+
+	total := 0.0
+	for i := range s.customers {
+		name := firstNames[s.rng.IntN(len(firstNames))] + " " + lastNames[s.rng.IntN(len(lastNames))]
+		key := strings.ToLower(name)
+		seen[key]++
+		if seen[key] > 1 {
+			name += " " + strconv.Itoa(seen[key])
+		}
+
+		// A Pareto affinity, capped so nobody visits several times a day,
+		// gives a few regulars most of the repeat visits.
+		affinity := math.Min(1/math.Pow(1-s.rng.Float64(), 1/1.5), 30)
+		// Mean lifetime of half a year, so older cohorts churn.
+		lifetime := time.Duration(s.rng.ExpFloat64() * 180 * float64(24*time.Hour))
+
+		s.customers[i] = customerState{
+			Customer: entity.Customer{ID: s.newUUID(), Name: name, OpeningPoints: new(int)},
+			lifetime: lifetime,
+		}
+		total += affinity
+		s.customerWeight[i] = total
+	}
+}
+
+func (s *simulation) buildProducts() {
+	start := s.startDay()
+	end := s.endDay()
+	span := int(end.Sub(start).Hours()/24) + 30
+
+	typeWeights := make([]float64, len(productTypes))
+	for i, productType := range productTypes {
+		typeWeights[i] = productType.Weight
+	}
+
+	combos := len(entity.Flavors) * len(entity.Sizes)
+	s.products = make([]productState, s.options.Products)
+	for i := range s.products {
+		// Cycle through every flavor/size pair before repeating one.
+		flavor := entity.Flavors[(i%combos)/len(entity.Sizes)]
+		size := entity.Sizes[i%len(entity.Sizes)]
+		productType := productTypes[pickWeighted(s.rng, typeWeights)]
+
+		price := roundTo(float64(productType.BasePrice)*sizePriceFactor[size], 500)
+		costPrice := roundTo(float64(price)*(0.55+0.15*s.rng.Float64()), 100)
+
+		// The first full set is on the shelf before the range starts; later
+		// batches are produced throughout it.
+		manufactured := start.AddDate(0, 0, -s.rng.IntN(30))
+		if i >= combos {
+			manufactured = start.AddDate(0, 0, s.rng.IntN(span)-30)
+		}
+
+		sizeWeight := map[string]float64{entity.SizeSmall: 1.3, entity.SizeMedium: 1, entity.SizeLarge: 0.6}[size]
+		weight := flavorWeights[flavor] * sizeWeight * productType.Weight * (0.5 + s.rng.Float64())
+
+		s.products[i] = productState{
+			Product: entity.Product{
+				ID:               s.newUUID(),
+				Name:             productType.Name + " " + flavor + " " + size,
+				Type:             productType.Name,
+				Flavor:           flavor,
+				Size:             size,
+				Price:            price,
+				CostPrice:        &costPrice,
+				ManufacturedDate: manufactured,
+				CreatedAt:        manufactured,
+			},
+			weight: weight,
+		}
+	}
+
+	sort.SliceStable(s.products, func(i, j int) bool {
+		return s.products[i].ManufacturedDate.Before(s.products[j].ManufacturedDate)
+	})
+
+	s.productWeight = make([]float64, len(s.products))
+	total := 0.0
+	for i := range s.products {
+		total += s.products[i].weight
+		s.productWeight[i] = total
+	}
+}
+
+func (s *simulation) startDay() time.Time {
+	return time.Date(s.options.Start.Year(), s.options.Start.Month(), s.options.Start.Day(), 0, 0, 0, 0, s.location)
+}
+
+func (s *simulation) endDay() time.Time {
+	return time.Date(s.options.End.Year(), s.options.End.Month(), s.options.End.Day(), 0, 0, 0, 0, s.location)
+}
+
+func (s *simulation) run() error {
+	var days []time.Time
+	for day := s.startDay(); !day.After(s.endDay()); day = day.AddDate(0, 0, 1) {
+		days = append(days, day)
+	}
+
+	weights := make([]float64, len(days))
+	for i, day := range days {
+		weights[i] = DayWeight(day, float64(i)/float64(len(days)))
+	}
+	perDay := make([]int, len(days))
+	for i := 0; i < s.options.Transactions; i++ {
+		perDay[pickWeighted(s.rng, weights)]++
+	}
+
+	for i, day := range days {
+		times := make([]time.Time, perDay[i])
+		for j := range times {
+			times[j] = day.Add(time.Duration(pickWeighted(s.rng, hourWeights[:])) * time.Hour).
+				Add(time.Duration(s.rng.IntN(3600)) * time.Second)
+		}
+		sort.Slice(times, func(a, b int) bool { return times[a].Before(times[b]) })
+
+		for _, at := range times {
+			if err := s.transaction(at); err != nil {
+				return err
+			}
+		}
+	}
+
+	return s.flush(true)
+}
+
+func (s *simulation) transaction(at time.Time) error {
+	customer := s.pickCustomer(at)
+	product := s.pickProduct(at, nil)
+
+	qty := 1 + pickWeighted(s.rng, []float64{60, 25, 10, 3, 2})
+	unitPrice := product.Price
+	taxAmount, taxRate := s.taxPolicy.Calculate(product.Type, unitPrice*qty)
+	pointsEarned := entity.PointsEarned(taxAmount.Gross)
+
+	product.sold += qty
+	customer.Points += pointsEarned
+	customer.lastActive = at
+	pointsBalance := customer.Points
+
+	localDay := at.In(s.location)
+	receiptDate := time.Date(localDay.Year(), localDay.Month(), localDay.Day(), 0, 0, 0, 0, time.UTC)
+	s.receipts[receiptDate]++
+	receiptNo := entity.FormatReceiptNo(receiptDate, s.receipts[receiptDate])
+
+	s.transactions = append(s.transactions, entity.Transaction{
+		ID:            s.newUUID(),
+		ReceiptNo:     &receiptNo,
+		CustomerID:    customer.ID,
+		ProductID:     product.ID,
+		Qty:           qty,
+		UnitPrice:     unitPrice,
+		TotalPrice:    taxAmount.Gross,
+		NetAmount:     taxAmount.Net,
+		TaxAmount:     taxAmount.Tax,
+		TaxRateBps:    taxRate,
+		PointsEarned:  pointsEarned,
+		PointsBalance: &pointsBalance,
+		TransactionAt: at.UTC(),
+		CreatedAt:     at.UTC(),
+	})
+	s.transactionCount++
+	s.revenue += int64(taxAmount.Gross)
+
+	if customer.Points >= entity.PointsCost(entity.SizeSmall) && s.rng.Float64() < redemptionChance {
+		s.redeem(customer, at.Add(time.Second))
+	}
+
+	return s.flush(false)
+}
+
+// redeem spends points on one product of a size the customer can afford.
+func (s *simulation) redeem(customer *customerState, at time.Time) {
+	product := s.pickProduct(at, func(p *productState) bool {
+		return entity.PointsCost(p.Size) <= customer.Points
+	})
+	if product == nil {
+		return
+	}
+
+	cost := entity.PointsCost(product.Size)
+	product.sold++
+	customer.Points -= cost
+	customer.lastActive = at
+
+	s.redemptions = append(s.redemptions, entity.Redemption{
+		ID:          s.newUUID(),
+		CustomerID:  customer.ID,
+		ProductID:   product.ID,
+		Qty:         1,
+		PointsSpent: cost,
+		RedeemAt:    at.UTC(),
+		CreatedAt:   at.UTC(),
+	})
+	s.redemptionCount++
+}
+
+// pickCustomer brings in the next new customer often enough that all of them
+// arrive by the last transaction, and otherwise picks a returning customer
+// by affinity, skipping those past their lifetime where possible.
+func (s *simulation) pickCustomer(at time.Time) *customerState {
+	remaining := s.options.Transactions - s.transactionCount
+	newChance := float64(len(s.customers)-s.arrived) / float64(remaining)
+	if s.arrived == 0 || (s.arrived < len(s.customers) && s.rng.Float64() < newChance) {
+		customer := &s.customers[s.arrived]
+		customer.arrived = true
+		customer.CreatedAt = at.UTC()
+		s.arrived++
+		return customer
+	}
+
+	var customer *customerState
+	for attempt := 0; attempt < 4; attempt++ {
+		customer = &s.customers[pickCumulative(s.rng, s.customerWeight[:s.arrived])]
+		if at.Sub(customer.CreatedAt) <= customer.lifetime {
+			break
+		}
+	}
+	return customer
+}
+
+// pickProduct picks a product already manufactured by at, weighted by
+// popularity. With a filter it retries a few times and falls back to a scan.
+func (s *simulation) pickProduct(at time.Time, filter func(*productState) bool) *productState {
+	available := sort.Search(len(s.products), func(i int) bool {
+		return s.products[i].ManufacturedDate.After(at)
+	})
+	if available == 0 {
+		available = 1
+	}
+
+	for attempt := 0; attempt < 8; attempt++ {
+		product := &s.products[pickCumulative(s.rng, s.productWeight[:available])]
+		if filter == nil || filter(product) {
+			return product
+		}
+	}
+	for i := 0; i < available; i++ {
+		if filter(&s.products[i]) {
+			return &s.products[i]
+		}
+	}
+	return nil
+}
+
+func (s *simulation) flush(final bool) error {
+	if s.sink == nil {
+		s.transactions = s.transactions[:0]
+		s.redemptions = s.redemptions[:0]
+		return nil
+	}
+
+	if len(s.transactions) >= s.options.BatchSize || (final && len(s.transactions) > 0) {
+		if err := s.sink.Transactions(s.transactions); err != nil {
+			return err
+		}
+		s.transactions = nil
+	}
+	if len(s.redemptions) >= s.options.BatchSize || (final && len(s.redemptions) > 0) {
+		if err := s.sink.Redemptions(s.redemptions); err != nil {
+			return err
+		}
+		s.redemptions = nil
+	}
+	return nil
+}
+
+// finalState returns customers with their settled points, and products with
+// a random closing stock and the opening stock that covers it plus every unit
+// sold and redeemed, so stock never goes negative. Customers who never bought
+// anything are spread over the range with zero points.
+func (s *simulation) finalState() ([]entity.Customer, []entity.Product, map[uuid.UUID]int) {
+	start := s.startDay()
+	span := s.endDay().AddDate(0, 0, 1).Sub(start)
+
+	customers := make([]entity.Customer, len(s.customers))
+	for i := range s.customers {
+		customer := s.customers[i].Customer
+		if !s.customers[i].arrived {
+			customer.CreatedAt = start.Add(time.Duration(s.rng.Int64N(int64(span)))).UTC()
+			customer.UpdatedAt = customer.CreatedAt
+		} else {
+			customer.UpdatedAt = s.customers[i].lastActive.UTC()
+		}
+		customers[i] = customer
+	}
+
+	products := make([]entity.Product, len(s.products))
+	openingStock := make(map[uuid.UUID]int, len(s.products))
+	for i := range s.products {
+		product := s.products[i].Product
+		product.StockQty = 20 + s.rng.IntN(150)
+		product.UpdatedAt = product.CreatedAt
+		products[i] = product
+		openingStock[product.ID] = s.products[i].sold + product.StockQty
+	}
+
+	return customers, products, openingStock
+}
+
+func (s *simulation) receiptSequences() []entity.ReceiptSequence {
+	rows := make([]entity.ReceiptSequence, 0, len(s.receipts))
+	for date, last := range s.receipts {
+		rows = append(rows, entity.ReceiptSequence{ReceiptDate: date, LastNumber: last})
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].ReceiptDate.Before(rows[j].ReceiptDate) })
+	return rows
+}
+
+// hourWeights is the share of sales per local hour, with lunch and after
+// work peaks and the store closed overnight.
+var hourWeights = [24]float64{
+	0, 0, 0, 0, 0, 0, 0,
+	0.5, 1, 1.5, 2, 3, 4, 3.5, 2.5, 2.5, 3, 4, 4.5, 4, 3, 2, 1, 0,
+}
+
+// DayWeight is the relative number of sales on day, where progress is the
+// fraction of the generated range already elapsed. It combines a weekend
+// lift, a payday lift around the turn of the month, a yearly season peaking
+// in the dry season, and gradual growth.
+func DayWeight(day time.Time, progress float64) float64 {
+	weight := 1.0
+	switch day.Weekday() {
+	case time.Friday:
+		weight *= 1.15
+	case time.Saturday:
+		weight *= 1.4
+	case time.Sunday:
+		weight *= 1.3
+	}
+
+	if day.Day() >= 25 || day.Day() <= 2 {
+		weight *= 1.15
+	}
+
+	weight *= 1 + 0.2*math.Sin(2*math.Pi*float64(day.YearDay()-80)/365)
+	weight *= 1 + 0.3*progress
+	return weight
+}
+
+func pickWeighted(rng *rand.Rand, weights []float64) int {
+	total := 0.0
+	for _, weight := range weights {
+		total += weight
+	}
+
+	target := rng.Float64() * total
+	for i, weight := range weights {
+		if target < weight {
+			return i
+		}
+		target -= weight
+	}
+	return len(weights) - 1
+}
+
+func pickCumulative(rng *rand.Rand, cumulative []float64) int {
+	target := rng.Float64() * cumulative[len(cumulative)-1]
+	index := sort.SearchFloat64s(cumulative, target)
+	if index >= len(cumulative) {
+		index = len(cumulative) - 1
+	}
+	return index
+}
+
+func roundTo(value float64, step int) int {
+	return int(math.Round(value/float64(step))) * step
+}
@@ -0,0 +1,45 @@
+package generator
+
+var firstNames = []string{
+	"Adi", "Agus", "Ahmad", "Aisyah", "Andi", "Anisa", "Arif", "Ayu", "Bayu", "Budi",
+	"Citra", "Dani", "Dewi", "Dian", "Dimas", "Eka", "Endah", "Fajar", "Fery", "Fenty",
+	"Fitri", "Galih", "Gita", "Hadi", "Hana", "Hendra", "Ika", "Indah", "Intan", "Irfan",
+	"Joko", "Kartika", "Lestari", "Lina", "Maya", "Nanda", "Nur", "Putri", "Rahmat", "Rina",
+	"Rizky", "Sari", "Siti", "Slamet", "Taufik", "Tika", "Wahyu", "Wulan", "Yanti", "Yusuf",
+}
+
+var lastNames = []string{
+	"Pratama", "Saputra", "Wijaya", "Santoso", "Hidayat", "Nugroho", "Kurniawan", "Setiawan",
+	"Lestari", "Permata", "Utami", "Wibowo", "Siregar", "Harahap", "Nasution", "Simanjuntak",
+	"Gunawan", "Halim", "Susanto", "Rahayu", "Purnomo", "Syahputra", "Maharani", "Anggraini",
+}
+
+// productTypes are the snack lines with the Small price; Medium and Large
+// scale it by sizePriceFactor.
+var productTypes = []struct {
+	Name      string
+	BasePrice int
+	Weight    float64
+}{
+	{Name: "Keripik Pangsit", BasePrice: 10000, Weight: 4},
+	{Name: "Keripik Singkong", BasePrice: 8000, Weight: 3},
+	{Name: "Makaroni", BasePrice: 7000, Weight: 2},
+	{Name: "Basreng", BasePrice: 12000, Weight: 1.5},
+}
+
+// flavorWeights skews demand towards the best selling flavors.
+var flavorWeights = map[string]float64{
+	"Original":     1.4,
+	"Pedas":        1.3,
+	"Jagung Bakar": 1.1,
+	"Keju Asin":    1.0,
+	"Keju Manis":   0.9,
+	"Rumput Laut":  0.8,
+	"Jagung Manis": 0.7,
+}
+
+var sizePriceFactor = map[string]float64{
+	"Small":  1,
+	"Medium": 2.5,
+	"Large":  3.5,
+}
@@ -10,9 +10,19 @@ const (
 	TooManyRequests          = "Too many requests, please try again later"
 	Unauthorized             = "Unauthorized access"
 	ErrInvalidIDFormat       = "Invalid ID format"
+	ErrInvalidCursor         = "Invalid cursor"
+	ErrSegmentCursor         = "Segment filter does not support cursor pagination"
 	ConflictError            = "Resource conflict"
 	StatusNotFound           = "Resource not found"
 	ErrCreateProduct         = "Failed to create product"
 	ErrInsufficientStock     = "Insufficient stock"
 	ErrInsufficientPoints    = "Insufficient points"
+	ErrRenderReceipt         = "Failed to render receipt"
+	ErrExport                = "Failed to export data"
+	ErrShiftAlreadyOpen      = "Cashier already has an open shift"
+	ErrShiftClosed           = "Shift is already closed"
+	ErrNoOpenShift           = "No open shift, please open a shift first"
+	ErrShiftAmbiguous        = "Several shifts are open, please specify cashier_name"
+	ErrAdminDisabled         = "Admin endpoints are disabled"
+	ErrCacheWarmRange        = "Cache warm range is too long"
 )
@@ -8,6 +8,16 @@ const (
 	ProductCreated      = "Product created successfully"
 	TransactionCreated  = "Transaction created successfully"
 	TransactionsFetched = "Transactions fetched successfully"
+	TransactionFetched  = "Transaction fetched successfully"
 	RedemptionCreated   = "Redemption created successfully"
+	RedemptionsFetched  = "Redemptions fetched successfully"
+	RedemptionFetched   = "Redemption fetched successfully"
 	ReportFetched       = "Report fetched successfully"
+	ShiftOpened         = "Shift opened successfully"
+	ShiftClosed         = "Shift closed successfully"
+	ShiftFetched        = "Shift fetched successfully"
+	CacheStatsFetched   = "Cache stats fetched successfully"
+	CacheKeyFetched     = "Cache key fetched successfully"
+	CacheFlushed        = "Cache flushed successfully"
+	CacheWarmed         = "Cache warmed successfully"
 )
@@ -0,0 +1,98 @@
+package migrations
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// typeAliases maps the spellings used in gorm type tags to the names Postgres
+// reports through the driver.
+var typeAliases = map[string]string{
+	"bigint":                   "int8",
+	"integer":                  "int4",
+	"int":                      "int4",
+	"smallint":                 "int2",
+	"boolean":                  "bool",
+	"character varying":        "varchar",
+	"decimal":                  "numeric",
+	"timestamp with time zone": "timestamptz",
+}
+
+// CheckDrift compares the entities in Models with the live schema and returns
+// one line per difference: missing tables, columns and named indexes, columns
+// the entity declares NOT NULL that are nullable, and explicit type tags that
+// do not match. Check constraint expressions and default values are not
+// compared.
+func CheckDrift(db *gorm.DB) ([]string, error) {
+	var drift []string
+	migrator := db.Migrator()
+
+	for _, model := range Models() {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(model); err != nil {
+			return nil, err
+		}
+		table := stmt.Schema.Table
+
+		if !migrator.HasTable(model) {
+			drift = append(drift, fmt.Sprintf("table %s is missing", table))
+			continue
+		}
+
+		columnTypes, err := migrator.ColumnTypes(model)
+		if err != nil {
+			return nil, err
+		}
+		columns := make(map[string]gorm.ColumnType, len(columnTypes))
+		for _, columnType := range columnTypes {
+			columns[columnType.Name()] = columnType
+		}
+
+		for _, field := range stmt.Schema.Fields {
+			if field.DBName == "" {
+				continue
+			}
+
+			column, ok := columns[field.DBName]
+			if !ok {
+				drift = append(drift, fmt.Sprintf("column %s.%s is missing", table, field.DBName))
+				continue
+			}
+
+			if nullable, ok := column.Nullable(); ok && nullable && (field.NotNull || field.PrimaryKey) {
+				drift = append(drift, fmt.Sprintf("column %s.%s is nullable but the entity requires NOT NULL", table, field.DBName))
+			}
+
+			if tagType, ok := field.TagSettings["TYPE"]; ok {
+				want := normalizeType(tagType)
+				got := normalizeType(column.DatabaseTypeName())
+				if want != got {
+					drift = append(drift, fmt.Sprintf("column %s.%s has type %s, entity declares %s", table, field.DBName, got, want))
+				}
+			}
+		}
+
+		for _, index := range stmt.Schema.ParseIndexes() {
+			if !migrator.HasIndex(model, index.Name) {
+				drift = append(drift, fmt.Sprintf("index %s on %s is missing", index.Name, table))
+			}
+		}
+	}
+
+	return drift, nil
+}
+
+// normalizeType lowercases a type name and drops its length or precision so
+// varchar(20) and VARCHAR compare equal.
+func normalizeType(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if i := strings.IndexByte(name, '('); i >= 0 {
+		name = strings.TrimSpace(name[:i])
+	}
+	if alias, ok := typeAliases[name]; ok {
+		return alias
+	}
+	return name
+}
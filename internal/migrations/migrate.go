@@ -1,16 +1,108 @@
 package migrations
 
 import (
-	"snack-store-api/internal/entity"
+	"embed"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
 
-	"gorm.io/gorm"
+	"snack-store-api/internal/entity"
 )
 
-func Migrate(db *gorm.DB) error {
-	return db.AutoMigrate(
+//go:embed sql/*.sql
+var sqlFiles embed.FS
+
+// Models lists the entities whose tables are owned by the SQL migrations; the
+// drift check compares them against the database.
+func Models() []any {
+	return []any{
 		&entity.Customer{},
 		&entity.Product{},
+		&entity.Shift{},
 		&entity.Transaction{},
 		&entity.Redemption{},
-	)
+		&entity.ReceiptSequence{},
+		&entity.DailySalesSummary{},
+	}
+}
+
+// Migration is one numbered schema change with its rollback.
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+var migrationFileName = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// Embedded returns the migrations shipped with the binary.
+func Embedded() ([]Migration, error) {
+	sub, err := fs.Sub(sqlFiles, "sql")
+	if err != nil {
+		return nil, err
+	}
+	return ParseMigrations(sub)
+}
+
+// ParseMigrations reads NNNN_name.up.sql / NNNN_name.down.sql pairs from the
+// root of fsys and returns them ordered by version. Every version needs both
+// files and a single name.
+func ParseMigrations(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int64]*Migration{}
+	for _, file := range entries {
+		if file.IsDir() {
+			continue
+		}
+
+		match := migrationFileName.FindStringSubmatch(file.Name())
+		if match == nil {
+			return nil, fmt.Errorf("invalid migration file name %q", file.Name())
+		}
+
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("invalid migration version in %q", file.Name())
+		}
+
+		content, err := fs.ReadFile(fsys, file.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has conflicting names %q and %q", version, migration.Name, match[2])
+		}
+
+		if match[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s needs both up and down files", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
 }
@@ -0,0 +1,217 @@
+package migrations
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// migrationLockSQL takes a session level advisory lock so concurrent
+// replicas starting with --migrate-up apply each migration exactly once.
+const (
+	migrationLockSQL   = `SELECT pg_advisory_lock(hashtext('schema_migrations'))`
+	migrationUnlockSQL = `SELECT pg_advisory_unlock(hashtext('schema_migrations'))`
+)
+
+type SchemaMigration struct {
+	Version   int64     `gorm:"primaryKey;autoIncrement:false"`
+	Name      string    `gorm:"not null"`
+	AppliedAt time.Time `gorm:"not null;default:now()"`
+}
+
+func (s *SchemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// MigrationStatus is one row of --migrate-status.
+type MigrationStatus struct {
+	Version   int64
+	Name      string
+	AppliedAt *time.Time
+	// Missing marks a version recorded in the database without a file.
+	Missing bool
+}
+
+type Migrator struct {
+	DB         *gorm.DB
+	Log        *logrus.Logger
+	Migrations []Migration
+}
+
+func NewMigrator(db *gorm.DB, log *logrus.Logger) (*Migrator, error) {
+	migrations, err := Embedded()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{
+		DB:         db,
+		Log:        log,
+		Migrations: migrations,
+	}, nil
+}
+
+// Up applies every pending migration in version order, each in its own
+// transaction, and returns how many were applied.
+func (m *Migrator) Up() (int, error) {
+	applied := 0
+	err := m.withLock(func(conn *gorm.DB) error {
+		done, err := m.applied(conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.Migrations {
+			if _, ok := done[migration.Version]; ok {
+				continue
+			}
+
+			err := conn.Transaction(func(tx *gorm.DB) error {
+				if err := tx.Exec(migration.Up).Error; err != nil {
+					return err
+				}
+				return tx.Create(&SchemaMigration{Version: migration.Version, Name: migration.Name}).Error
+			})
+			if err != nil {
+				return fmt.Errorf("migration %d_%s up: %w", migration.Version, migration.Name, err)
+			}
+
+			m.Log.Infof("Applied migration %d_%s", migration.Version, migration.Name)
+			applied++
+		}
+
+		return nil
+	})
+
+	return applied, err
+}
+
+// Down rolls back the latest n applied migrations, newest first.
+func (m *Migrator) Down(n int) (int, error) {
+	byVersion := make(map[int64]Migration, len(m.Migrations))
+	for _, migration := range m.Migrations {
+		byVersion[migration.Version] = migration
+	}
+
+	rolledBack := 0
+	err := m.withLock(func(conn *gorm.DB) error {
+		var latest []SchemaMigration
+		if err := conn.Order("version DESC").Limit(n).Find(&latest).Error; err != nil {
+			return err
+		}
+
+		for _, row := range latest {
+			migration, ok := byVersion[row.Version]
+			if !ok {
+				return fmt.Errorf("migration %d_%s has no down file in this build", row.Version, row.Name)
+			}
+
+			err := conn.Transaction(func(tx *gorm.DB) error {
+				if err := tx.Exec(migration.Down).Error; err != nil {
+					return err
+				}
+				return tx.Delete(&SchemaMigration{}, row.Version).Error
+			})
+			if err != nil {
+				return fmt.Errorf("migration %d_%s down: %w", migration.Version, migration.Name, err)
+			}
+
+			m.Log.Infof("Rolled back migration %d_%s", migration.Version, migration.Name)
+			rolledBack++
+		}
+
+		return nil
+	})
+
+	return rolledBack, err
+}
+
+// Status lists every known migration with its applied time, followed by any
+// applied version this build does not know about.
+func (m *Migrator) Status() ([]MigrationStatus, error) {
+	if err := m.ensureTable(m.DB); err != nil {
+		return nil, err
+	}
+
+	done, err := m.applied(m.DB)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, 0, len(m.Migrations))
+	known := make(map[int64]bool, len(m.Migrations))
+	for _, migration := range m.Migrations {
+		known[migration.Version] = true
+		status := MigrationStatus{Version: migration.Version, Name: migration.Name}
+		if row, ok := done[migration.Version]; ok {
+			appliedAt := row.AppliedAt
+			status.AppliedAt = &appliedAt
+		}
+		statuses = append(statuses, status)
+	}
+
+	for version, row := range done {
+		if known[version] {
+			continue
+		}
+		appliedAt := row.AppliedAt
+		statuses = append(statuses, MigrationStatus{Version: version, Name: row.Name, AppliedAt: &appliedAt, Missing: true})
+	}
+
+	return statuses, nil
+}
+
+// Version returns the latest applied migration version, or 0 on an empty
+// database.
+func (m *Migrator) Version() (int64, error) {
+	if err := m.ensureTable(m.DB); err != nil {
+		return 0, err
+	}
+
+	var version int64
+	err := m.DB.Model(&SchemaMigration{}).Select("COALESCE(MAX(version), 0)").Scan(&version).Error
+	return version, err
+}
+
+// withLock runs fn on a single pooled connection holding the migration lock.
+func (m *Migrator) withLock(fn func(conn *gorm.DB) error) error {
+	return m.DB.Connection(func(conn *gorm.DB) error {
+		if err := conn.Exec(migrationLockSQL).Error; err != nil {
+			return fmt.Errorf("acquire migration lock: %w", err)
+		}
+		defer func() {
+			if err := conn.Exec(migrationUnlockSQL).Error; err != nil {
+				m.Log.Warnf("Failed to release migration lock : %+v", err)
+			}
+		}()
+
+		if err := m.ensureTable(conn); err != nil {
+			return err
+		}
+		return fn(conn)
+	})
+}
+
+func (m *Migrator) ensureTable(db *gorm.DB) error {
+	return db.Exec(`
+CREATE TABLE IF NOT EXISTS schema_migrations (
+  version bigint PRIMARY KEY,
+  name text NOT NULL,
+  applied_at timestamptz NOT NULL DEFAULT now()
+)`).Error
+}
+
+func (m *Migrator) applied(db *gorm.DB) (map[int64]SchemaMigration, error) {
+	var rows []SchemaMigration
+	if err := db.Order("version").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	done := make(map[int64]SchemaMigration, len(rows))
+	for _, row := range rows {
+		done[row.Version] = row
+	}
+	return done, nil
+}
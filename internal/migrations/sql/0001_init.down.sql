@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS redemptions;
+DROP TABLE IF EXISTS transactions;
+DROP TABLE IF EXISTS customers;
+DROP TABLE IF EXISTS products;
+
+DROP FUNCTION IF EXISTS set_updated_at();
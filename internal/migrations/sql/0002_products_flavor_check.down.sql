@@ -0,0 +1,4 @@
+ALTER TABLE products DROP CONSTRAINT IF EXISTS chk_products_flavor;
+
+ALTER TABLE products ADD CONSTRAINT products_flavor_check
+  CHECK (length(btrim(flavor)) > 0);
@@ -0,0 +1,7 @@
+-- The entity and the create product request only accept the listed flavors;
+-- the initial schema merely required a non-empty value.
+ALTER TABLE products DROP CONSTRAINT IF EXISTS products_flavor_check;
+ALTER TABLE products DROP CONSTRAINT IF EXISTS chk_products_flavor;
+
+ALTER TABLE products ADD CONSTRAINT chk_products_flavor
+  CHECK (flavor IN ('Jagung Bakar', 'Rumput Laut', 'Original', 'Jagung Manis', 'Keju Asin', 'Keju Manis', 'Pedas'));
@@ -0,0 +1,3 @@
+ALTER TABLE transactions DROP COLUMN IF EXISTS tax_rate_bps;
+ALTER TABLE transactions DROP COLUMN IF EXISTS tax_amount;
+ALTER TABLE transactions DROP COLUMN IF EXISTS net_amount;
@@ -0,0 +1,12 @@
+-- Rows written before tax was tracked keep zeroes until tax backfill splits
+-- their totals.
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS net_amount integer NOT NULL DEFAULT 0;
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS tax_amount integer NOT NULL DEFAULT 0;
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS tax_rate_bps integer NOT NULL DEFAULT 0;
+
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS transactions_net_amount_check;
+ALTER TABLE transactions ADD CONSTRAINT transactions_net_amount_check CHECK (net_amount >= 0);
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS transactions_tax_amount_check;
+ALTER TABLE transactions ADD CONSTRAINT transactions_tax_amount_check CHECK (tax_amount >= 0);
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS transactions_tax_rate_bps_check;
+ALTER TABLE transactions ADD CONSTRAINT transactions_tax_rate_bps_check CHECK (tax_rate_bps >= 0);
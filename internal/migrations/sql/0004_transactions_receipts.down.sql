@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS receipt_sequences;
+
+DROP INDEX IF EXISTS transactions_receipt_no_key;
+
+ALTER TABLE transactions DROP COLUMN IF EXISTS points_balance;
+ALTER TABLE transactions DROP COLUMN IF EXISTS receipt_no;
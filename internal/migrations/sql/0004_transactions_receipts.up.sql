@@ -0,0 +1,16 @@
+-- Older transactions have no receipt number or recorded balance; receipts for
+-- them fall back to the short transaction id.
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS receipt_no varchar(20);
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS points_balance integer;
+
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS transactions_points_balance_check;
+ALTER TABLE transactions ADD CONSTRAINT transactions_points_balance_check CHECK (points_balance >= 0);
+
+CREATE UNIQUE INDEX IF NOT EXISTS transactions_receipt_no_key ON transactions (receipt_no);
+
+CREATE TABLE IF NOT EXISTS receipt_sequences (
+  receipt_date date PRIMARY KEY,
+  last_number integer NOT NULL DEFAULT 0,
+  updated_at timestamptz NOT NULL DEFAULT now(),
+  CHECK (last_number >= 0)
+);
@@ -0,0 +1,4 @@
+ALTER TABLE redemptions DROP COLUMN IF EXISTS shift_id;
+ALTER TABLE transactions DROP COLUMN IF EXISTS shift_id;
+
+DROP TABLE IF EXISTS shifts;
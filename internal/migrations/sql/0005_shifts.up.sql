@@ -0,0 +1,39 @@
+CREATE TABLE IF NOT EXISTS shifts (
+  id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  cashier_name text NOT NULL,
+  status varchar(10) NOT NULL DEFAULT 'open',
+  opening_float integer NOT NULL,
+  expected_cash integer,
+  counted_cash integer,
+  notes text NOT NULL DEFAULT '',
+  opened_at timestamptz NOT NULL,
+  closed_at timestamptz,
+  created_at timestamptz NOT NULL DEFAULT now(),
+  updated_at timestamptz NOT NULL DEFAULT now(),
+  CHECK (length(btrim(cashier_name)) > 0),
+  CHECK (status IN ('open', 'closed')),
+  CHECK (opening_float >= 0),
+  CHECK (counted_cash >= 0)
+);
+
+CREATE INDEX IF NOT EXISTS shifts_cashier_name_idx ON shifts (cashier_name);
+CREATE INDEX IF NOT EXISTS shifts_opened_at_idx ON shifts (opened_at);
+CREATE UNIQUE INDEX IF NOT EXISTS shifts_single_open_key ON shifts (status) WHERE status = 'open';
+
+DROP TRIGGER IF EXISTS shifts_set_updated_at ON shifts;
+CREATE TRIGGER shifts_set_updated_at
+BEFORE UPDATE ON shifts
+FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+
+-- Sales and redemptions recorded before shifts existed stay unassigned.
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS shift_id uuid;
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS transactions_shift_id_fkey;
+ALTER TABLE transactions ADD CONSTRAINT transactions_shift_id_fkey
+  FOREIGN KEY (shift_id) REFERENCES shifts(id) ON DELETE RESTRICT;
+CREATE INDEX IF NOT EXISTS transactions_shift_id_idx ON transactions (shift_id);
+
+ALTER TABLE redemptions ADD COLUMN IF NOT EXISTS shift_id uuid;
+ALTER TABLE redemptions DROP CONSTRAINT IF EXISTS redemptions_shift_id_fkey;
+ALTER TABLE redemptions ADD CONSTRAINT redemptions_shift_id_fkey
+  FOREIGN KEY (shift_id) REFERENCES shifts(id) ON DELETE RESTRICT;
+CREATE INDEX IF NOT EXISTS redemptions_shift_id_idx ON redemptions (shift_id);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS redemptions_redeem_at_id_idx;
+DROP INDEX IF EXISTS transactions_transaction_at_id_idx;
+DROP INDEX IF EXISTS customers_created_at_id_idx;
@@ -0,0 +1,4 @@
+-- Keyset pagination orders by (timestamp, id).
+CREATE INDEX IF NOT EXISTS customers_created_at_id_idx ON customers (created_at, id);
+CREATE INDEX IF NOT EXISTS transactions_transaction_at_id_idx ON transactions (transaction_at, id);
+CREATE INDEX IF NOT EXISTS redemptions_redeem_at_id_idx ON redemptions (redeem_at, id);
@@ -0,0 +1,6 @@
+-- NULL means the cost is unknown; inventory valuation reports such products
+-- separately instead of counting them at zero.
+ALTER TABLE products ADD COLUMN IF NOT EXISTS cost_price integer;
+
+ALTER TABLE products DROP CONSTRAINT IF EXISTS products_cost_price_check;
+ALTER TABLE products ADD CONSTRAINT products_cost_price_check CHECK (cost_price >= 0);
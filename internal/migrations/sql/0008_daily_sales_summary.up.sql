@@ -0,0 +1,15 @@
+-- Starts empty; run summaries rebuild to fill it from existing transactions.
+CREATE TABLE IF NOT EXISTS daily_sales_summary (
+  sales_date date NOT NULL,
+  product_id uuid NOT NULL REFERENCES products(id) ON DELETE RESTRICT,
+  qty bigint NOT NULL DEFAULT 0,
+  revenue bigint NOT NULL DEFAULT 0,
+  transaction_count bigint NOT NULL DEFAULT 0,
+  updated_at timestamptz NOT NULL DEFAULT now(),
+  PRIMARY KEY (sales_date, product_id),
+  CHECK (qty >= 0),
+  CHECK (revenue >= 0),
+  CHECK (transaction_count >= 0)
+);
+
+CREATE INDEX IF NOT EXISTS daily_sales_summary_product_id_idx ON daily_sales_summary (product_id);
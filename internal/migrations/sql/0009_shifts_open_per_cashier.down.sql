@@ -0,0 +1,5 @@
+-- Fails while more than one shift is open; close the extra shifts first.
+DROP INDEX IF EXISTS shifts_open_cashier_key;
+
+CREATE UNIQUE INDEX IF NOT EXISTS shifts_single_open_key
+  ON shifts (status) WHERE status = 'open';
@@ -0,0 +1,6 @@
+-- Each cashier works their own drawer, so only one open shift per cashier is
+-- enforced instead of one for the whole store.
+DROP INDEX IF EXISTS shifts_single_open_key;
+
+CREATE UNIQUE INDEX IF NOT EXISTS shifts_open_cashier_key
+  ON shifts (cashier_name) WHERE status = 'open';
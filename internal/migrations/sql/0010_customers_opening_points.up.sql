@@ -0,0 +1,36 @@
+-- opening_points is the balance a customer carried over from before the
+-- transaction and redemption ledger, so points recalc can tell a legitimate
+-- carried-over balance from drift. NULL means unknown.
+ALTER TABLE customers ADD COLUMN IF NOT EXISTS opening_points integer;
+
+ALTER TABLE customers DROP CONSTRAINT IF EXISTS customers_opening_points_check;
+ALTER TABLE customers
+  ADD CONSTRAINT customers_opening_points_check CHECK (opening_points >= 0);
+
+-- Existing balances are taken as correct: whatever the ledger does not explain
+-- becomes the opening balance. Customers holding fewer points than the ledger
+-- implies stay NULL and are reported by points recalc instead of rewritten.
+ALTER TABLE customers DISABLE TRIGGER customers_set_updated_at;
+
+UPDATE customers c
+SET opening_points = ledger.opening_points
+FROM (
+  SELECT c.id,
+         c.points - COALESCE(e.earned, 0) + COALESCE(s.spent, 0) AS opening_points
+  FROM customers c
+  LEFT JOIN (
+    SELECT customer_id, SUM(points_earned) AS earned
+    FROM transactions
+    GROUP BY customer_id
+  ) e ON e.customer_id = c.id
+  LEFT JOIN (
+    SELECT customer_id, SUM(points_spent) AS spent
+    FROM redemptions
+    GROUP BY customer_id
+  ) s ON s.customer_id = c.id
+) ledger
+WHERE ledger.id = c.id
+  AND c.opening_points IS NULL
+  AND ledger.opening_points >= 0;
+
+ALTER TABLE customers ENABLE TRIGGER customers_set_updated_at;
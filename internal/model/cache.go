@@ -0,0 +1,49 @@
+package model
+
+type CacheNamespaceStats struct {
+	Namespace    string  `json:"namespace"`
+	Hits         int64   `json:"hits"`
+	Misses       int64   `json:"misses"`
+	HitRate      float64 `json:"hit_rate"`
+	Errors       int64   `json:"errors"`
+	Operations   int64   `json:"operations"`
+	AvgLatencyMs float64 `json:"avg_latency_ms"`
+	MaxLatencyMs float64 `json:"max_latency_ms"`
+}
+
+type CacheStatsResponse struct {
+	Namespaces []*CacheNamespaceStats `json:"namespaces"`
+}
+
+type CacheKeyRequest struct {
+	Key string `json:"-" validate:"required"`
+}
+
+type CacheKeyResponse struct {
+	Key       string `json:"key"`
+	Namespace string `json:"namespace"`
+	Exists    bool   `json:"exists"`
+	// TTLMs is nil when the key is missing or never expires.
+	TTLMs *int64 `json:"ttl_ms"`
+}
+
+type FlushCacheRequest struct {
+	Namespace string `json:"-" validate:"required,oneof=products reports"`
+}
+
+type FlushCacheResponse struct {
+	Namespace string `json:"namespace"`
+	Prefix    string `json:"prefix"`
+}
+
+type WarmCacheRequest struct {
+	Start string `json:"start" validate:"required,datetime=2006-01-02"`
+	End   string `json:"end" validate:"required,datetime=2006-01-02"`
+}
+
+type WarmCacheResponse struct {
+	Start        string `json:"start"`
+	End          string `json:"end"`
+	ProductDates int    `json:"product_dates"`
+	Reports      int    `json:"reports"`
+}
@@ -1,8 +1,13 @@
 package converter
 
 import (
+	"strconv"
+
+	"snack-store-api/internal/constants"
 	"snack-store-api/internal/entity"
+	"snack-store-api/internal/export"
 	"snack-store-api/internal/model"
+	"snack-store-api/internal/repository"
 )
 
 func CustomerToResponse(customer *entity.Customer) *model.CustomerResponse {
@@ -11,3 +16,43 @@ func CustomerToResponse(customer *entity.Customer) *model.CustomerResponse {
 		Points: customer.Points,
 	}
 }
+
+var CustomerRFMCSVHeader = []string{
+	"id", "name", "points", "first_purchase_at", "last_purchase_at", "recency_days",
+	"frequency", "monetary", "recency_score", "frequency_score", "monetary_score", "segment",
+}
+
+func CustomerRFMToResponse(row *repository.CustomerRFMRow, score entity.RFMScore) *model.CustomerResponse {
+	return &model.CustomerResponse{
+		Name:   row.Name,
+		Points: row.Points,
+		RFM: &model.CustomerRFMResponse{
+			FirstPurchaseAt: row.FirstPurchaseAt.Format(constants.DateTimeLayout),
+			LastPurchaseAt:  row.LastPurchaseAt.Format(constants.DateTimeLayout),
+			RecencyDays:     score.RecencyDays,
+			Frequency:       row.Frequency,
+			Monetary:        row.Monetary,
+			RecencyScore:    score.RecencyScore,
+			FrequencyScore:  score.FrequencyScore,
+			MonetaryScore:   score.MonetaryScore,
+			Segment:         score.Segment,
+		},
+	}
+}
+
+func CustomerRFMToCSVRecord(row *repository.CustomerRFMRow, score entity.RFMScore) []string {
+	return []string{
+		row.CustomerID.String(),
+		export.EscapeFormula(row.Name),
+		strconv.Itoa(row.Points),
+		row.FirstPurchaseAt.Format(constants.DateTimeLayout),
+		row.LastPurchaseAt.Format(constants.DateTimeLayout),
+		strconv.Itoa(score.RecencyDays),
+		strconv.Itoa(row.Frequency),
+		strconv.FormatInt(row.Monetary, 10),
+		strconv.Itoa(score.RecencyScore),
+		strconv.Itoa(score.FrequencyScore),
+		strconv.Itoa(score.MonetaryScore),
+		score.Segment,
+	}
+}
@@ -15,6 +15,7 @@ func ProductToResponse(product *entity.Product) *model.ProductResponse {
 		Flavor:           product.Flavor,
 		Size:             product.Size,
 		Price:            product.Price,
+		CostPrice:        product.CostPrice,
 		StockQty:         product.StockQty,
 		ManufacturedDate: product.ManufacturedDate.Format(constants.DateLayout),
 	}
@@ -10,9 +10,11 @@ func RedemptionToResponse(redemption *entity.Redemption) *model.RedemptionRespon
 	id := redemption.ID
 	return &model.RedemptionResponse{
 		ID:           &id,
+		ShiftID:      redemption.ShiftID,
 		CustomerName: redemption.Customer.Name,
 		ProductName:  redemption.Product.Name,
 		Size:         redemption.Product.Size,
+		Flavor:       redemption.Product.Flavor,
 		Qty:          redemption.Qty,
 		PointsSpent:  redemption.PointsSpent,
 		RedeemAt:     redemption.RedeemAt.Format(constants.DateTimeLayout),
@@ -0,0 +1,52 @@
+package converter
+
+import (
+	"time"
+
+	"snack-store-api/internal/constants"
+	"snack-store-api/internal/model"
+	"snack-store-api/internal/repository"
+	"snack-store-api/internal/utils"
+)
+
+// CohortActivityToResponse turns sparse activity rows into one retention row
+// per month offset, zero-filling offsets without purchases. Offsets that lie
+// after now are left out rather than reported as zero retention.
+func CohortActivityToResponse(rows []repository.CohortActivityRow, months int, now time.Time) []*model.ReportCohort {
+	currentMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	cohorts := make([]*model.ReportCohort, 0)
+	active := make(map[int]int64)
+
+	flush := func(cohortMonth time.Time, size int64) {
+		cohort := &model.ReportCohort{
+			Cohort:    cohortMonth.Format(constants.MonthLayout),
+			Size:      size,
+			Retention: make([]*model.ReportCohortRetention, 0, months+1),
+		}
+		for offset := 0; offset <= months; offset++ {
+			month := cohortMonth.AddDate(0, offset, 0)
+			if month.After(currentMonth) {
+				break
+			}
+			cohort.Retention = append(cohort.Retention, &model.ReportCohortRetention{
+				MonthOffset:    offset,
+				Month:          month.Format(constants.MonthLayout),
+				ActiveCustomer: active[offset],
+				Rate:           utils.Percentage(active[offset], size),
+			})
+		}
+		cohorts = append(cohorts, cohort)
+		active = make(map[int]int64)
+	}
+
+	for i, row := range rows {
+		if row.MonthOffset != nil {
+			active[*row.MonthOffset] = row.ActiveCustomer
+		}
+		if i == len(rows)-1 || !rows[i+1].CohortMonth.Equal(row.CohortMonth) {
+			flush(row.CohortMonth, row.CohortSize)
+		}
+	}
+
+	return cohorts
+}
@@ -0,0 +1,27 @@
+package converter
+
+import (
+	"snack-store-api/internal/constants"
+	"snack-store-api/internal/entity"
+	"snack-store-api/internal/model"
+)
+
+func ShiftToResponse(shift *entity.Shift) *model.ShiftResponse {
+	id := shift.ID
+	response := &model.ShiftResponse{
+		ID:           &id,
+		CashierName:  shift.CashierName,
+		Status:       shift.Status,
+		OpeningFloat: shift.OpeningFloat,
+		ExpectedCash: shift.ExpectedCash,
+		CountedCash:  shift.CountedCash,
+		Notes:        shift.Notes,
+		OpenedAt:     shift.OpenedAt.Format(constants.DateTimeLayout),
+	}
+
+	if shift.ClosedAt != nil {
+		response.ClosedAt = shift.ClosedAt.Format(constants.DateTimeLayout)
+	}
+
+	return response
+}
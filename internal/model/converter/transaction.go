@@ -1,15 +1,20 @@
 package converter
 
 import (
+	"strings"
+
 	"snack-store-api/internal/constants"
 	"snack-store-api/internal/entity"
 	"snack-store-api/internal/model"
+
+	"github.com/google/uuid"
 )
 
 func TransactionToResponse(transaction *entity.Transaction) *model.TransactionResponse {
 	id := transaction.ID
-	return &model.TransactionResponse{
+	response := &model.TransactionResponse{
 		ID:            &id,
+		ShiftID:       transaction.ShiftID,
 		CustomerName:  transaction.Customer.Name,
 		ProductName:   transaction.Product.Name,
 		Size:          transaction.Product.Size,
@@ -17,7 +22,56 @@ func TransactionToResponse(transaction *entity.Transaction) *model.TransactionRe
 		Qty:           transaction.Qty,
 		UnitPrice:     transaction.UnitPrice,
 		TotalPrice:    transaction.TotalPrice,
+		NetAmount:     transaction.NetAmount,
+		TaxAmount:     transaction.TaxAmount,
+		TaxRate:       float64(transaction.TaxRateBps) / 100,
 		PointsEarned:  transaction.PointsEarned,
+		PointsBalance: transaction.PointsBalance,
 		TransactionAt: transaction.TransactionAt.Format(constants.DateTimeLayout),
 	}
+
+	if transaction.ReceiptNo != nil {
+		response.ReceiptNo = *transaction.ReceiptNo
+	}
+
+	return response
+}
+
+func TransactionToReceipt(transaction *entity.Transaction) *model.ReceiptResponse {
+	// Transactions from before receipt numbering print a short id; the full
+	// UUID does not fit a 58mm line.
+	receiptNo := ShortTransactionID(transaction.ID)
+	if transaction.ReceiptNo != nil {
+		receiptNo = *transaction.ReceiptNo
+	}
+
+	return &model.ReceiptResponse{
+		ReceiptNo:     receiptNo,
+		TransactionID: transaction.ID.String(),
+		CustomerName:  transaction.Customer.Name,
+		Items: []model.ReceiptItem{
+			{
+				ProductName: transaction.Product.Name,
+				Size:        transaction.Product.Size,
+				Flavor:      transaction.Product.Flavor,
+				Qty:         transaction.Qty,
+				UnitPrice:   transaction.UnitPrice,
+				Subtotal:    transaction.UnitPrice * transaction.Qty,
+			},
+		},
+		NetAmount:     transaction.NetAmount,
+		TaxAmount:     transaction.TaxAmount,
+		TaxRate:       float64(transaction.TaxRateBps) / 100,
+		TotalPrice:    transaction.TotalPrice,
+		PointsEarned:  transaction.PointsEarned,
+		PointsBalance: transaction.PointsBalance,
+		TransactionAt: transaction.TransactionAt,
+	}
+}
+
+// ShortTransactionID is the first eight hex digits of the id. It is only a
+// printed label for receipts without a number; transactions are looked up by
+// their full id.
+func ShortTransactionID(id uuid.UUID) string {
+	return "TRX-" + strings.ToUpper(id.String()[:8])
 }
@@ -1,11 +1,27 @@
 package model
 
 type GetCustomerRequest struct {
-	Page     int `json:"-" validate:"gte=1"`
-	PageSize int `json:"-" validate:"gte=1"`
+	Page      int    `json:"-" validate:"gte=1"`
+	PageSize  int    `json:"-" validate:"gte=1"`
+	Cursor    string `json:"-"`
+	WithCount bool   `json:"-"`
+	Segment   string `json:"-" validate:"omitempty,oneof=champions new at_risk lost regular"`
 }
 
 type CustomerResponse struct {
-	Name   string `json:"name,omitempty"`
-	Points int    `json:"points,omitempty"`
+	Name   string               `json:"name,omitempty"`
+	Points int                  `json:"points,omitempty"`
+	RFM    *CustomerRFMResponse `json:"rfm,omitempty"`
+}
+
+type CustomerRFMResponse struct {
+	FirstPurchaseAt string `json:"first_purchase_at"`
+	LastPurchaseAt  string `json:"last_purchase_at"`
+	RecencyDays     int    `json:"recency_days"`
+	Frequency       int    `json:"frequency"`
+	Monetary        int64  `json:"monetary"`
+	RecencyScore    int    `json:"recency_score"`
+	FrequencyScore  int    `json:"frequency_score"`
+	MonetaryScore   int    `json:"monetary_score"`
+	Segment         string `json:"segment"`
 }
@@ -1,10 +1,11 @@
 package model
 
 type WebResponse[T any] struct {
-	Message string         `json:"message,omitempty"`
-	Data    T              `json:"data,omitempty"`
-	Paging  *PageMetadata  `json:"paging,omitempty"`
-	Error   *ErrorResponse `json:"error,omitempty"`
+	Message string          `json:"message,omitempty"`
+	Data    T               `json:"data,omitempty"`
+	Paging  *PageMetadata   `json:"paging,omitempty"`
+	Cursor  *CursorMetadata `json:"cursor,omitempty"`
+	Error   *ErrorResponse  `json:"error,omitempty"`
 }
 
 type ErrorResponse struct {
@@ -20,3 +21,10 @@ type PageMetadata struct {
 	HasNext     bool  `json:"has_next"`
 	HasPrevious bool  `json:"has_previous"`
 }
+
+type CursorMetadata struct {
+	PageSize   int    `json:"page_size"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	HasNext    bool   `json:"has_next"`
+	TotalItem  *int64 `json:"total_item,omitempty"`
+}
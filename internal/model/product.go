@@ -12,6 +12,7 @@ type CreateProductRequest struct {
 	Flavor           string `json:"flavor" validate:"required,oneof='Jagung Bakar' 'Rumput Laut' 'Original' 'Jagung Manis' 'Keju Asin' 'Keju Manis' 'Pedas'"`
 	Size             string `json:"size" validate:"required,oneof=Small Medium Large"`
 	Price            int    `json:"price" validate:"required,gte=0"`
+	CostPrice        *int   `json:"cost_price" validate:"omitempty,gte=0"`
 	StockQty         int    `json:"stock_qty" validate:"required,gte=0"`
 	ManufacturedDate string `json:"manufactured_date" validate:"required,datetime=2006-01-02"`
 }
@@ -23,6 +24,7 @@ type ProductResponse struct {
 	Flavor           string     `json:"flavor,omitempty"`
 	Size             string     `json:"size,omitempty"`
 	Price            int        `json:"price,omitempty"`
+	CostPrice        *int       `json:"cost_price,omitempty"`
 	StockQty         int        `json:"stock_qty,omitempty"`
 	ManufacturedDate string     `json:"manufactured_date,omitempty"`
 }
@@ -7,13 +7,33 @@ type CreateRedemptionRequest struct {
 	ProductID    string `json:"product_id" validate:"required"`
 	Qty          int    `json:"qty" validate:"required,gt=0"`
 	RedeemAt     string `json:"redeem_at" validate:"required"`
+	CashierName  string `json:"cashier_name"`
+}
+
+type GetRedemptionRequest struct {
+	Start        string `json:"-" validate:"required,datetime=2006-01-02"`
+	End          string `json:"-" validate:"required,datetime=2006-01-02"`
+	CustomerName string `json:"-"`
+	ProductID    string `json:"-" validate:"omitempty,uuid"`
+	Size         string `json:"-" validate:"omitempty,oneof=Small Medium Large"`
+	Sort         string `json:"-" validate:"omitempty,oneof=asc desc"`
+	Page         int    `json:"-" validate:"gte=1"`
+	PageSize     int    `json:"-" validate:"gte=1"`
+	Cursor       string `json:"-"`
+	WithCount    bool   `json:"-"`
+}
+
+type GetRedemptionDetailRequest struct {
+	ID string `json:"-" validate:"required,uuid"`
 }
 
 type RedemptionResponse struct {
 	ID           *uuid.UUID `json:"redemption_id,omitempty"`
+	ShiftID      *uuid.UUID `json:"shift_id,omitempty"`
 	CustomerName string     `json:"customer_name,omitempty"`
 	ProductName  string     `json:"product_name,omitempty"`
 	Size         string     `json:"size,omitempty"`
+	Flavor       string     `json:"flavor,omitempty"`
 	Qty          int        `json:"qty,omitempty"`
 	PointsSpent  int        `json:"points_spent,omitempty"`
 	RedeemAt     string     `json:"redeem_at,omitempty"`
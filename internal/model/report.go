@@ -3,8 +3,11 @@ package model
 import "github.com/google/uuid"
 
 type ReportTransactionsRequest struct {
-	Start string `json:"-" validate:"required,datetime=2006-01-02"`
-	End   string `json:"-" validate:"required,datetime=2006-01-02"`
+	Start   string `json:"-" validate:"required,datetime=2006-01-02"`
+	End     string `json:"-" validate:"required,datetime=2006-01-02"`
+	Compare string `json:"-" validate:"omitempty,oneof=previous yoy"`
+	Format  string `json:"-" validate:"omitempty,oneof=csv xlsx"`
+	Locale  string `json:"-" validate:"omitempty,oneof=id en"`
 }
 
 type ReportBestSeller struct {
@@ -33,6 +36,262 @@ type ReportTransactionsResponse struct {
 	HasNewCustomer    bool                     `json:"has_new_customer"`
 	TotalIncome       int                      `json:"total_income"`
 	BestSeller        *ReportBestSeller        `json:"best_seller,omitempty"`
+	BestSellers       []*ReportBestSeller      `json:"best_sellers,omitempty"`
 	TotalProductsSold int                      `json:"total_products_sold"`
 	LastTransactions  []*ReportTransactionItem `json:"last_transactions,omitempty"`
+	Comparison        *ReportComparison        `json:"comparison,omitempty"`
+}
+
+type ReportMetricDelta struct {
+	Current       int64    `json:"current"`
+	Previous      int64    `json:"previous"`
+	Change        int64    `json:"change"`
+	ChangePercent *float64 `json:"change_percent"`
+}
+
+type ReportTransactionsDeltas struct {
+	TotalCustomer     ReportMetricDelta `json:"total_customer"`
+	TotalIncome       ReportMetricDelta `json:"total_income"`
+	TotalProductsSold ReportMetricDelta `json:"total_products_sold"`
+}
+
+type ReportComparison struct {
+	Mode   string                      `json:"mode"`
+	Start  string                      `json:"start"`
+	End    string                      `json:"end"`
+	Report *ReportTransactionsResponse `json:"report"`
+	Deltas ReportTransactionsDeltas    `json:"deltas"`
+}
+
+type ReportTaxRequest struct {
+	Start string `json:"-" validate:"required,datetime=2006-01-02"`
+	End   string `json:"-" validate:"required,datetime=2006-01-02"`
+}
+
+type ReportTaxPeriod struct {
+	Period           string  `json:"period"`
+	TaxRate          float64 `json:"tax_rate"`
+	TotalTransaction int64   `json:"total_transaction"`
+	NetAmount        int64   `json:"net_amount"`
+	TaxAmount        int64   `json:"tax_amount"`
+	GrossAmount      int64   `json:"gross_amount"`
+}
+
+type ReportTaxResponse struct {
+	Start       string             `json:"start"`
+	End         string             `json:"end"`
+	NetAmount   int64              `json:"net_amount"`
+	TaxAmount   int64              `json:"tax_amount"`
+	GrossAmount int64              `json:"gross_amount"`
+	Periods     []*ReportTaxPeriod `json:"periods"`
+}
+
+type ReportSalesRequest struct {
+	Start       string `json:"-" validate:"required,datetime=2006-01-02"`
+	End         string `json:"-" validate:"required,datetime=2006-01-02"`
+	Granularity string `json:"-" validate:"required,oneof=day week month"`
+}
+
+type ReportSalesBucket struct {
+	Period           string `json:"period"`
+	Income           int64  `json:"income"`
+	QtySold          int64  `json:"qty_sold"`
+	TotalTransaction int64  `json:"total_transaction"`
+	TotalCustomer    int64  `json:"total_customer"`
+}
+
+type ReportSalesResponse struct {
+	Start            string               `json:"start"`
+	End              string               `json:"end"`
+	Granularity      string               `json:"granularity"`
+	TotalIncome      int64                `json:"total_income"`
+	TotalQtySold     int64                `json:"total_qty_sold"`
+	TotalTransaction int64                `json:"total_transaction"`
+	TotalCustomer    int64                `json:"total_customer"`
+	Buckets          []*ReportSalesBucket `json:"buckets"`
+}
+
+type ReportBreakdownRequest struct {
+	Start   string `json:"-" validate:"required,datetime=2006-01-02"`
+	End     string `json:"-" validate:"required,datetime=2006-01-02"`
+	GroupBy string `json:"-" validate:"required,oneof=flavor size type product"`
+	SortBy  string `json:"-" validate:"required,oneof=revenue qty"`
+	Limit   int    `json:"-" validate:"gte=1,lte=100"`
+}
+
+type ReportBreakdownItem struct {
+	Rank             int     `json:"rank"`
+	Key              string  `json:"key"`
+	ProductName      string  `json:"product_name,omitempty"`
+	Size             string  `json:"size,omitempty"`
+	Flavor           string  `json:"flavor,omitempty"`
+	Type             string  `json:"type,omitempty"`
+	QtySold          int64   `json:"qty_sold"`
+	Revenue          int64   `json:"revenue"`
+	TotalTransaction int64   `json:"total_transaction"`
+	QtyShare         float64 `json:"qty_share"`
+	RevenueShare     float64 `json:"revenue_share"`
+}
+
+type ReportBreakdownResponse struct {
+	Start        string                 `json:"start"`
+	End          string                 `json:"end"`
+	GroupBy      string                 `json:"group_by"`
+	SortBy       string                 `json:"sort_by"`
+	TotalQtySold int64                  `json:"total_qty_sold"`
+	TotalRevenue int64                  `json:"total_revenue"`
+	Items        []*ReportBreakdownItem `json:"items"`
+}
+
+type ReportCohortRequest struct {
+	Start     string `json:"-" validate:"required,datetime=2006-01-02"`
+	End       string `json:"-" validate:"required,datetime=2006-01-02"`
+	Months    int    `json:"-" validate:"gte=1,lte=24"`
+	ChurnDays int    `json:"-" validate:"gte=1,lte=365"`
+}
+
+type ReportCohortRetention struct {
+	MonthOffset    int     `json:"month_offset"`
+	Month          string  `json:"month"`
+	ActiveCustomer int64   `json:"active_customer"`
+	Rate           float64 `json:"rate"`
+}
+
+type ReportCohort struct {
+	Cohort    string                   `json:"cohort"`
+	Size      int64                    `json:"size"`
+	Retention []*ReportCohortRetention `json:"retention"`
+}
+
+type ReportCohortResponse struct {
+	Start                string          `json:"start"`
+	End                  string          `json:"end"`
+	Months               int             `json:"months"`
+	ChurnDays            int             `json:"churn_days"`
+	TotalCustomer        int64           `json:"total_customer"`
+	RepeatCustomer       int64           `json:"repeat_customer"`
+	RepeatPurchaseRate   float64         `json:"repeat_purchase_rate"`
+	AvgDaysBetweenVisits float64         `json:"avg_days_between_visits"`
+	ChurnedCustomer      int64           `json:"churned_customer"`
+	ChurnRate            float64         `json:"churn_rate"`
+	Cohorts              []*ReportCohort `json:"cohorts"`
+}
+
+type ReportLoyaltyRequest struct {
+	Start       string `json:"-" validate:"required,datetime=2006-01-02"`
+	End         string `json:"-" validate:"required,datetime=2006-01-02"`
+	Granularity string `json:"-" validate:"required,oneof=day week month"`
+}
+
+type ReportPointsBucket struct {
+	Period         string `json:"period"`
+	PointsEarned   int64  `json:"points_earned"`
+	PointsRedeemed int64  `json:"points_redeemed"`
+}
+
+type ReportRedeemedProduct struct {
+	ProductName     string `json:"product_name"`
+	Size            string `json:"size"`
+	Flavor          string `json:"flavor"`
+	TotalQty        int64  `json:"total_qty"`
+	PointsSpent     int64  `json:"points_spent"`
+	TotalRedemption int64  `json:"total_redemption"`
+}
+
+type ReportLoyaltyResponse struct {
+	Start               string                   `json:"start"`
+	End                 string                   `json:"end"`
+	Granularity         string                   `json:"granularity"`
+	OutstandingPoints   int64                    `json:"outstanding_points"`
+	CustomersWithPoints int64                    `json:"customers_with_points"`
+	PointValue          *float64                 `json:"point_value"`
+	EstimatedLiability  *int64                   `json:"estimated_liability"`
+	PointRetailValue    *float64                 `json:"point_retail_value"`
+	RetailLiability     *int64                   `json:"estimated_retail_liability"`
+	PointsEarned        int64                    `json:"points_earned"`
+	PointsRedeemed      int64                    `json:"points_redeemed"`
+	RedemptionRate      float64                  `json:"redemption_rate"`
+	TopRedeemedProducts []*ReportRedeemedProduct `json:"top_redeemed_products"`
+	Buckets             []*ReportPointsBucket    `json:"buckets"`
+}
+
+type ReportInventoryRequest struct {
+	VelocityDays  int `json:"-" validate:"gte=1,lte=365"`
+	DeadStockDays int `json:"-" validate:"gte=1,lte=730"`
+}
+
+type ReportInventoryItem struct {
+	ProductID        string   `json:"product_id"`
+	Name             string   `json:"name"`
+	Type             string   `json:"type"`
+	Flavor           string   `json:"flavor"`
+	Size             string   `json:"size"`
+	ManufacturedDate string   `json:"manufactured_date"`
+	AgeDays          int      `json:"age_days"`
+	AgeBucket        string   `json:"age_bucket"`
+	StockQty         int64    `json:"stock_qty"`
+	Price            int64    `json:"price"`
+	CostPrice        *int64   `json:"cost_price"`
+	StockValue       int64    `json:"stock_value"`
+	StockCost        *int64   `json:"stock_cost"`
+	RecentQtySold    int64    `json:"recent_qty_sold"`
+	DailyVelocity    float64  `json:"daily_velocity"`
+	DaysOfCover      *float64 `json:"days_of_cover"`
+	LastSoldAt       *string  `json:"last_sold_at"`
+	IsDeadStock      bool     `json:"is_dead_stock"`
+}
+
+type ReportStockAging struct {
+	Bucket     string `json:"bucket"`
+	StockQty   int64  `json:"stock_qty"`
+	StockValue int64  `json:"stock_value"`
+}
+
+type ReportInventoryResponse struct {
+	AsOf             string                 `json:"as_of"`
+	VelocityDays     int                    `json:"velocity_days"`
+	DeadStockDays    int                    `json:"dead_stock_days"`
+	TotalStockQty    int64                  `json:"total_stock_qty"`
+	TotalStockValue  int64                  `json:"total_stock_value"`
+	TotalStockCost   int64                  `json:"total_stock_cost"`
+	UnknownCostItems int                    `json:"unknown_cost_items"`
+	DeadStockItems   int                    `json:"dead_stock_items"`
+	DeadStockValue   int64                  `json:"dead_stock_value"`
+	Aging            []*ReportStockAging    `json:"aging"`
+	Items            []*ReportInventoryItem `json:"items"`
+}
+
+type ReportHeatmapRequest struct {
+	Start string `json:"-" validate:"required,datetime=2006-01-02"`
+	End   string `json:"-" validate:"required,datetime=2006-01-02"`
+}
+
+type ReportHeatmapCell struct {
+	Hour             int   `json:"hour"`
+	TotalTransaction int64 `json:"total_transaction"`
+	Revenue          int64 `json:"revenue"`
+}
+
+type ReportHeatmapDay struct {
+	Weekday          string               `json:"weekday"`
+	TotalTransaction int64                `json:"total_transaction"`
+	Revenue          int64                `json:"revenue"`
+	Hours            []*ReportHeatmapCell `json:"hours"`
+}
+
+type ReportHeatmapPeak struct {
+	Weekday          string `json:"weekday"`
+	Hour             int    `json:"hour"`
+	TotalTransaction int64  `json:"total_transaction"`
+	Revenue          int64  `json:"revenue"`
+}
+
+type ReportHeatmapResponse struct {
+	Start            string              `json:"start"`
+	End              string              `json:"end"`
+	Timezone         string              `json:"timezone"`
+	TotalTransaction int64               `json:"total_transaction"`
+	Revenue          int64               `json:"revenue"`
+	Peak             *ReportHeatmapPeak  `json:"peak,omitempty"`
+	Days             []*ReportHeatmapDay `json:"days"`
 }
@@ -0,0 +1,50 @@
+package model
+
+import "github.com/google/uuid"
+
+type OpenShiftRequest struct {
+	CashierName  string `json:"cashier_name" validate:"required"`
+	OpeningFloat *int   `json:"opening_float" validate:"required,gte=0"`
+	OpenedAt     string `json:"opened_at"`
+}
+
+type CloseShiftRequest struct {
+	ID          string `json:"-" validate:"required,uuid"`
+	CountedCash *int   `json:"counted_cash" validate:"required,gte=0"`
+	ClosedAt    string `json:"closed_at"`
+	Notes       string `json:"notes"`
+}
+
+type GetActiveShiftRequest struct {
+	CashierName string `json:"-"`
+}
+
+type GetShiftRequest struct {
+	ID string `json:"-" validate:"required,uuid"`
+}
+
+type ShiftResponse struct {
+	ID           *uuid.UUID `json:"shift_id,omitempty"`
+	CashierName  string     `json:"cashier_name,omitempty"`
+	Status       string     `json:"status,omitempty"`
+	OpeningFloat int        `json:"opening_float"`
+	ExpectedCash *int       `json:"expected_cash,omitempty"`
+	CountedCash  *int       `json:"counted_cash,omitempty"`
+	Notes        string     `json:"notes,omitempty"`
+	OpenedAt     string     `json:"opened_at,omitempty"`
+	ClosedAt     string     `json:"closed_at,omitempty"`
+}
+
+type ShiftReportResponse struct {
+	Shift            *ShiftResponse `json:"shift"`
+	TotalTransaction int64          `json:"total_transaction"`
+	TotalQtySold     int64          `json:"total_qty_sold"`
+	TotalSales       int64          `json:"total_sales"`
+	TotalTax         int64          `json:"total_tax"`
+	TotalRedemption  int64          `json:"total_redemption"`
+	TotalQtyRedeemed int64          `json:"total_qty_redeemed"`
+	TotalPointsSpent int64          `json:"total_points_spent"`
+	ExpectedCash     int64          `json:"expected_cash"`
+	CountedCash      *int64         `json:"counted_cash,omitempty"`
+	Difference       *int64         `json:"difference,omitempty"`
+}
@@ -1,23 +1,74 @@
 package model
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 type CreateTransactionRequest struct {
 	CustomerName  string `json:"customer_name" validate:"required"`
 	ProductID     string `json:"product_id" validate:"required"`
 	Qty           int    `json:"qty" validate:"required,gt=0"`
 	TransactionAt string `json:"transaction_at" validate:"required"`
+	CashierName   string `json:"cashier_name"`
 }
 
 type GetTransactionRequest struct {
-	Start    string `json:"-" validate:"required,datetime=2006-01-02"`
-	End      string `json:"-" validate:"required,datetime=2006-01-02"`
-	Page     int    `json:"-" validate:"gte=1"`
-	PageSize int    `json:"-" validate:"gte=1"`
+	Start        string `json:"-" validate:"required,datetime=2006-01-02"`
+	End          string `json:"-" validate:"required,datetime=2006-01-02"`
+	CustomerName string `json:"-"`
+	ProductID    string `json:"-" validate:"omitempty,uuid"`
+	Flavor       string `json:"-" validate:"omitempty,oneof='Jagung Bakar' 'Rumput Laut' 'Original' 'Jagung Manis' 'Keju Asin' 'Keju Manis' 'Pedas'"`
+	Size         string `json:"-" validate:"omitempty,oneof=Small Medium Large"`
+	MinTotal     *int   `json:"-" validate:"omitempty,gte=0"`
+	MaxTotal     *int   `json:"-" validate:"omitempty,gte=0"`
+	Sort         string `json:"-" validate:"omitempty,oneof=asc desc"`
+	Page         int    `json:"-" validate:"gte=1"`
+	PageSize     int    `json:"-" validate:"gte=1"`
+	Cursor       string `json:"-"`
+	WithCount    bool   `json:"-"`
+	Format       string `json:"-" validate:"omitempty,oneof=csv xlsx"`
+	Locale       string `json:"-" validate:"omitempty,oneof=id en"`
+}
+
+type GetTransactionDetailRequest struct {
+	ID string `json:"-" validate:"required,uuid"`
+}
+
+type GetTransactionReceiptRequest struct {
+	ID       string `json:"-" validate:"required,uuid"`
+	Format   string `json:"-" validate:"omitempty,oneof=text html pdf"`
+	Download bool   `json:"-"`
+}
+
+type ReceiptItem struct {
+	ProductName string `json:"product_name"`
+	Size        string `json:"size"`
+	Flavor      string `json:"flavor"`
+	Qty         int    `json:"qty"`
+	UnitPrice   int    `json:"unit_price"`
+	Subtotal    int    `json:"subtotal"`
+}
+
+type ReceiptResponse struct {
+	ReceiptNo     string        `json:"receipt_no"`
+	TransactionID string        `json:"transaction_id"`
+	CustomerName  string        `json:"customer_name"`
+	Items         []ReceiptItem `json:"items"`
+	NetAmount     int           `json:"net_amount"`
+	TaxAmount     int           `json:"tax_amount"`
+	TaxRate       float64       `json:"tax_rate"`
+	TotalPrice    int           `json:"total_price"`
+	PointsEarned  int           `json:"points_earned"`
+	PointsBalance *int          `json:"points_balance,omitempty"`
+	TransactionAt time.Time     `json:"transaction_at"`
 }
 
 type TransactionResponse struct {
 	ID            *uuid.UUID `json:"transaction_id,omitempty"`
+	ReceiptNo     string     `json:"receipt_no,omitempty"`
+	ShiftID       *uuid.UUID `json:"shift_id,omitempty"`
 	CustomerName  string     `json:"customer_name,omitempty"`
 	ProductName   string     `json:"product_name,omitempty"`
 	Size          string     `json:"size,omitempty"`
@@ -25,6 +76,10 @@ type TransactionResponse struct {
 	Qty           int        `json:"qty,omitempty"`
 	UnitPrice     int        `json:"unit_price,omitempty"`
 	TotalPrice    int        `json:"total_price,omitempty"`
+	NetAmount     int        `json:"net_amount"`
+	TaxAmount     int        `json:"tax_amount"`
+	TaxRate       float64    `json:"tax_rate"`
 	PointsEarned  int        `json:"points_earned,omitempty"`
+	PointsBalance *int       `json:"points_balance,omitempty"`
 	TransactionAt string     `json:"transaction_at,omitempty"`
 }
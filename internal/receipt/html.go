@@ -0,0 +1,90 @@
+package receipt
+
+import (
+	"bytes"
+	"html/template"
+
+	"snack-store-api/internal/model"
+)
+
+var htmlTemplate = template.Must(template.New("receipt").Funcs(template.FuncMap{
+	"amount":      FormatAmount,
+	"rate":        formatRate,
+	"description": itemDescription,
+	"deref":       func(value *int) int { return *value },
+}).Parse(`<!DOCTYPE html>
+<html lang="id">
+<head>
+<meta charset="utf-8">
+<title>Receipt {{.Receipt.ReceiptNo}}</title>
+<style>
+  @page { size: 58mm auto; margin: 0; }
+  body { width: 54mm; margin: 0 auto; padding: 2mm 0; font-family: "Courier New", monospace; font-size: 11px; }
+  .center { text-align: center; }
+  .right { text-align: right; }
+  hr { border: 0; border-top: 1px dashed #000; margin: 4px 0; }
+  table { width: 100%; border-collapse: collapse; }
+  td { padding: 0; vertical-align: top; }
+  .total td { font-weight: bold; }
+</style>
+</head>
+<body onload="window.print && window.print()">
+  <div class="center"><strong>{{.Store.Name}}</strong></div>
+  {{- if .Store.Address}}<div class="center">{{.Store.Address}}</div>{{end}}
+  {{- if .Store.Phone}}<div class="center">{{.Store.Phone}}</div>{{end}}
+  <hr>
+  <table>
+    <tr><td>No</td><td class="right">{{.Receipt.ReceiptNo}}</td></tr>
+    <tr><td>Date</td><td class="right">{{.Date}}</td></tr>
+    <tr><td>Cust</td><td class="right">{{.Receipt.CustomerName}}</td></tr>
+  </table>
+  <hr>
+  <table>
+    {{- range .Receipt.Items}}
+    <tr><td colspan="2">{{.ProductName}}</td></tr>
+    <tr><td colspan="2">&nbsp;{{description .}}</td></tr>
+    <tr><td>&nbsp;{{.Qty}} x {{amount .UnitPrice}}</td><td class="right">{{amount .Subtotal}}</td></tr>
+    {{- end}}
+  </table>
+  <hr>
+  <table>
+    {{- if gt .Receipt.TaxAmount 0}}
+    <tr><td>Subtotal (DPP)</td><td class="right">{{amount .Receipt.NetAmount}}</td></tr>
+    <tr><td>PPN {{rate .Receipt.TaxRate}}</td><td class="right">{{amount .Receipt.TaxAmount}}</td></tr>
+    {{- end}}
+    <tr class="total"><td>TOTAL</td><td class="right">Rp {{amount .Receipt.TotalPrice}}</td></tr>
+  </table>
+  <hr>
+  <table>
+    <tr><td>Points earned</td><td class="right">{{amount .Receipt.PointsEarned}}</td></tr>
+    {{- if .Receipt.PointsBalance}}
+    <tr><td>Points balance</td><td class="right">{{amount (deref .Receipt.PointsBalance)}}</td></tr>
+    {{- end}}
+  </table>
+  {{- if .Store.Footer}}
+  <hr>
+  <div class="center">{{.Store.Footer}}</div>
+  {{- end}}
+</body>
+</html>
+`))
+
+type htmlData struct {
+	Store   Store
+	Receipt *model.ReceiptResponse
+	Date    string
+}
+
+func (r *Renderer) HTML(receipt *model.ReceiptResponse) ([]byte, error) {
+	var buf bytes.Buffer
+	err := htmlTemplate.Execute(&buf, htmlData{
+		Store:   r.Store,
+		Receipt: receipt,
+		Date:    r.formatTime(receipt.TransactionAt),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
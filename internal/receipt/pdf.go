@@ -0,0 +1,44 @@
+package receipt
+
+import (
+	"bytes"
+
+	"snack-store-api/internal/model"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+const (
+	pdfPageWidth  = 58.0
+	pdfMargin     = 2.0
+	pdfFontSize   = 8.0
+	pdfLineHeight = 3.6
+)
+
+// PDF lays out the same lines as the text receipt on a 58mm wide page.
+func (r *Renderer) PDF(receipt *model.ReceiptResponse) ([]byte, error) {
+	lines := r.lines(receipt)
+	pageHeight := float64(len(lines))*pdfLineHeight + pdfMargin*2
+
+	pdf := gofpdf.NewCustom(&gofpdf.InitType{
+		UnitStr: "mm",
+		Size:    gofpdf.SizeType{Wd: pdfPageWidth, Ht: pageHeight},
+	})
+	pdf.SetMargins(pdfMargin, pdfMargin, pdfMargin)
+	pdf.SetAutoPageBreak(false, pdfMargin)
+	pdf.SetTitle("Receipt "+receipt.ReceiptNo, true)
+	pdf.AddPage()
+	pdf.SetFont("Courier", "", pdfFontSize)
+
+	translate := pdf.UnicodeTranslatorFromDescriptor("")
+	for _, line := range lines {
+		pdf.CellFormat(0, pdfLineHeight, translate(line), "", 1, "L", false, 0, "")
+	}
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
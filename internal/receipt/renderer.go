@@ -0,0 +1,98 @@
+package receipt
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"snack-store-api/internal/model"
+)
+
+// LineWidth is the number of characters printed per line on 58mm thermal paper.
+const LineWidth = 32
+
+const (
+	FormatText = "text"
+	FormatHTML = "html"
+	FormatPDF  = "pdf"
+)
+
+type Store struct {
+	Name    string
+	Address string
+	Phone   string
+	Footer  string
+}
+
+type Renderer struct {
+	Store    Store
+	Location *time.Location
+}
+
+func NewRenderer(store Store, location *time.Location) *Renderer {
+	if location == nil {
+		location = time.UTC
+	}
+
+	return &Renderer{
+		Store:    store,
+		Location: location,
+	}
+}
+
+func (r *Renderer) Render(format string, receipt *model.ReceiptResponse) ([]byte, string, error) {
+	switch format {
+	case FormatHTML:
+		body, err := r.HTML(receipt)
+		return body, "text/html; charset=utf-8", err
+	case FormatPDF:
+		body, err := r.PDF(receipt)
+		return body, "application/pdf", err
+	default:
+		return r.Text(receipt), "text/plain; charset=utf-8", nil
+	}
+}
+
+func FileExtension(format string) string {
+	switch format {
+	case FormatHTML:
+		return "html"
+	case FormatPDF:
+		return "pdf"
+	default:
+		return "txt"
+	}
+}
+
+func (r *Renderer) formatTime(t time.Time) string {
+	return t.In(r.Location).Format("02/01/2006 15:04")
+}
+
+// FormatAmount formats an integer amount using Indonesian thousand separators.
+func FormatAmount(amount int) string {
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	digits := strconv.Itoa(amount)
+	var b strings.Builder
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte('.')
+		}
+		b.WriteRune(d)
+	}
+
+	return sign + b.String()
+}
+
+func formatRate(rate float64) string {
+	return strconv.FormatFloat(rate, 'f', -1, 64) + "%"
+}
+
+func itemDescription(item model.ReceiptItem) string {
+	return fmt.Sprintf("%s %s", item.Size, item.Flavor)
+}
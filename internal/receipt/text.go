@@ -0,0 +1,137 @@
+package receipt
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"snack-store-api/internal/model"
+)
+
+func (r *Renderer) Text(receipt *model.ReceiptResponse) []byte {
+	return []byte(strings.Join(r.lines(receipt), "\n") + "\n")
+}
+
+func (r *Renderer) lines(receipt *model.ReceiptResponse) []string {
+	separator := strings.Repeat("-", LineWidth)
+	lines := make([]string, 0, 32)
+
+	lines = append(lines, center(r.Store.Name))
+	for _, line := range []string{r.Store.Address, r.Store.Phone} {
+		if strings.TrimSpace(line) != "" {
+			lines = append(lines, wrap(line, LineWidth, center)...)
+		}
+	}
+
+	lines = append(lines,
+		separator,
+		field("No   : ", receipt.ReceiptNo),
+		field("Date : ", r.formatTime(receipt.TransactionAt)),
+		field("Cust : ", receipt.CustomerName),
+		separator,
+	)
+
+	for _, item := range receipt.Items {
+		lines = append(lines, wrap(item.ProductName, LineWidth, nil)...)
+		lines = append(lines, " "+truncate(itemDescription(item), LineWidth-1))
+		quantity := fmt.Sprintf(" %d x %s", item.Qty, FormatAmount(item.UnitPrice))
+		lines = append(lines, justify(quantity, FormatAmount(item.Subtotal)))
+	}
+
+	lines = append(lines, separator)
+	if receipt.TaxAmount > 0 {
+		lines = append(lines,
+			justify("Subtotal (DPP)", FormatAmount(receipt.NetAmount)),
+			justify("PPN "+formatRate(receipt.TaxRate), FormatAmount(receipt.TaxAmount)),
+		)
+	}
+	lines = append(lines,
+		justify("TOTAL", "Rp "+FormatAmount(receipt.TotalPrice)),
+		separator,
+		justify("Points earned", FormatAmount(receipt.PointsEarned)),
+	)
+	if receipt.PointsBalance != nil {
+		lines = append(lines, justify("Points balance", FormatAmount(*receipt.PointsBalance)))
+	}
+
+	if strings.TrimSpace(r.Store.Footer) != "" {
+		lines = append(lines, separator)
+		lines = append(lines, wrap(r.Store.Footer, LineWidth, center)...)
+	}
+
+	return lines
+}
+
+func center(text string) string {
+	text = truncate(strings.TrimSpace(text), LineWidth)
+	padding := (LineWidth - utf8.RuneCountInString(text)) / 2
+	return strings.Repeat(" ", padding) + text
+}
+
+// field prints a labelled header value, cut to the paper width.
+func field(label, value string) string {
+	return label + truncate(value, LineWidth-utf8.RuneCountInString(label))
+}
+
+func justify(left, right string) string {
+	space := LineWidth - utf8.RuneCountInString(left) - utf8.RuneCountInString(right)
+	if space < 1 {
+		left = truncate(left, LineWidth-utf8.RuneCountInString(right)-1)
+		space = 1
+	}
+
+	return left + strings.Repeat(" ", space) + right
+}
+
+func truncate(text string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+
+	runes := []rune(text)
+	if len(runes) <= width {
+		return text
+	}
+
+	return string(runes[:width])
+}
+
+func wrap(text string, width int, format func(string) string) []string {
+	words := strings.Fields(text)
+	lines := make([]string, 0, 2)
+	current := ""
+
+	flush := func() {
+		if current == "" {
+			return
+		}
+		if format != nil {
+			current = format(current)
+		}
+		lines = append(lines, current)
+		current = ""
+	}
+
+	for _, word := range words {
+		for utf8.RuneCountInString(word) > width {
+			flush()
+			runes := []rune(word)
+			current = string(runes[:width])
+			word = string(runes[width:])
+		}
+
+		switch {
+		case word == "":
+		case current == "":
+			current = word
+		case utf8.RuneCountInString(current)+1+utf8.RuneCountInString(word) <= width:
+			current += " " + word
+		default:
+			flush()
+			current = word
+		}
+	}
+	flush()
+
+	return lines
+}
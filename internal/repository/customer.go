@@ -1,26 +1,52 @@
 package repository
 
 import (
+	"time"
+
 	"snack-store-api/internal/entity"
 
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
 )
 
+type CustomerRFMRow struct {
+	CustomerID      uuid.UUID `gorm:"column:customer_id"`
+	Name            string    `gorm:"column:name"`
+	Points          int       `gorm:"column:points"`
+	FirstPurchaseAt time.Time `gorm:"column:first_purchase_at"`
+	LastPurchaseAt  time.Time `gorm:"column:last_purchase_at"`
+	Frequency       int       `gorm:"column:frequency"`
+	Monetary        int64     `gorm:"column:monetary"`
+}
+
 type CustomerRepository struct {
 	Repository[entity.Customer]
-	Log *logrus.Logger
+	Log      *logrus.Logger
+	Location *time.Location
 }
 
-func NewCustomerRepository(log *logrus.Logger) *CustomerRepository {
+func NewCustomerRepository(log *logrus.Logger, location *time.Location) *CustomerRepository {
 	return &CustomerRepository{
-		Log: log,
+		Log:      log,
+		Location: location,
 	}
 }
 
 func (r *CustomerRepository) FindAll(db *gorm.DB, limit int, offset int) ([]entity.Customer, error) {
 	var customers []entity.Customer
-	err := db.Order("created_at desc").Limit(limit).Offset(offset).Find(&customers).Error
+	err := db.Order("created_at desc, id desc").Limit(limit).Offset(offset).Find(&customers).Error
+	return customers, err
+}
+
+func (r *CustomerRepository) FindAfter(db *gorm.DB, after *Keyset, limit int) ([]entity.Customer, error) {
+	var customers []entity.Customer
+	query := db.Order("created_at desc, id desc").Limit(limit)
+	if after != nil {
+		query = query.Where(keysetCondition("created_at", "id", false), after.At, after.ID)
+	}
+
+	err := query.Find(&customers).Error
 	return customers, err
 }
 
@@ -29,3 +55,92 @@ func (r *CustomerRepository) CountAll(db *gorm.DB) (int64, error) {
 	err := db.Model(&entity.Customer{}).Count(&total).Error
 	return total, err
 }
+
+// FindRFMStats returns the recency, frequency (distinct store-local purchase
+// days) and monetary inputs of every customer with at least one transaction, ordered by
+// monetary value. Scores are relative to the whole population, so they are
+// assigned by entity.ScoreRFM over the full result.
+func (r *CustomerRepository) FindRFMStats(db *gorm.DB) ([]CustomerRFMRow, error) {
+	var rows []CustomerRFMRow
+	err := db.Raw(`
+SELECT c.id AS customer_id, c.name, c.points,
+       MIN(t.transaction_at) AS first_purchase_at,
+       MAX(t.transaction_at) AS last_purchase_at,
+       COUNT(DISTINCT CAST(t.transaction_at AT TIME ZONE ? AS date)) AS frequency,
+       SUM(t.total_price) AS monetary
+FROM customers c
+JOIN transactions t ON t.customer_id = c.id
+GROUP BY c.id, c.name, c.points
+ORDER BY monetary DESC, c.id
+`, r.Location.String()).Scan(&rows).Error
+	return rows, err
+}
+
+type PointsMismatchRow struct {
+	CustomerID    uuid.UUID `gorm:"column:customer_id"`
+	Name          string    `gorm:"column:name"`
+	Points        int       `gorm:"column:points"`
+	OpeningPoints *int      `gorm:"column:opening_points"`
+	Earned        int64     `gorm:"column:earned"`
+	Spent         int64     `gorm:"column:spent"`
+}
+
+// customerPointsLedger derives every customer's balance from the opening
+// balance plus the points earned on transactions minus the points spent on
+// redemptions. expected_points is NULL when the opening balance is unknown.
+const customerPointsLedger = `
+SELECT c.id AS customer_id,
+       c.name,
+       c.points,
+       c.opening_points,
+       COALESCE(e.earned, 0) AS earned,
+       COALESCE(s.spent, 0) AS spent,
+       c.opening_points + COALESCE(e.earned, 0) - COALESCE(s.spent, 0) AS expected_points
+FROM customers c
+LEFT JOIN (
+  SELECT customer_id, SUM(points_earned) AS earned
+  FROM transactions
+  GROUP BY customer_id
+) e ON e.customer_id = c.id
+LEFT JOIN (
+  SELECT customer_id, SUM(points_spent) AS spent
+  FROM redemptions
+  GROUP BY customer_id
+) s ON s.customer_id = c.id`
+
+// FindPointsMismatches returns customers whose stored balance differs from
+// the ledger or who have no opening balance to compare with; classify them
+// with entity.ReconcilePoints.
+func (r *CustomerRepository) FindPointsMismatches(db *gorm.DB) ([]PointsMismatchRow, error) {
+	var rows []PointsMismatchRow
+	err := db.Raw(`
+SELECT customer_id, name, points, opening_points, earned, spent
+FROM (` + customerPointsLedger + `
+) ledger
+WHERE points IS DISTINCT FROM expected_points
+ORDER BY name, customer_id
+`).Scan(&rows).Error
+	return rows, err
+}
+
+// RecalculatePoints overwrites the balances of customerIDs with the ledger
+// value in a single statement and returns the number of customers updated.
+// Customers without an opening balance or with a negative ledger balance are
+// never touched.
+func (r *CustomerRepository) RecalculatePoints(db *gorm.DB, customerIDs []uuid.UUID) (int64, error) {
+	if len(customerIDs) == 0 {
+		return 0, nil
+	}
+
+	result := db.Exec(`
+UPDATE customers c
+SET points = ledger.expected_points,
+    updated_at = now()
+FROM (`+customerPointsLedger+`
+) ledger
+WHERE ledger.customer_id = c.id
+  AND c.id IN ?
+  AND ledger.expected_points >= 0
+  AND c.points <> ledger.expected_points`, customerIDs)
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"time"
+
+	"snack-store-api/internal/constants"
+	"snack-store-api/internal/entity"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type SummaryMismatchRow struct {
+	SalesDate           time.Time `gorm:"column:sales_date"`
+	ProductID           uuid.UUID `gorm:"column:product_id"`
+	SummaryQty          int64     `gorm:"column:summary_qty"`
+	ActualQty           int64     `gorm:"column:actual_qty"`
+	SummaryRevenue      int64     `gorm:"column:summary_revenue"`
+	ActualRevenue       int64     `gorm:"column:actual_revenue"`
+	SummaryTransactions int64     `gorm:"column:summary_transactions"`
+	ActualTransactions  int64     `gorm:"column:actual_transactions"`
+}
+
+type DailySalesSummaryRepository struct {
+	Repository[entity.DailySalesSummary]
+	Log      *logrus.Logger
+	Location *time.Location
+}
+
+func NewDailySalesSummaryRepository(log *logrus.Logger, location *time.Location) *DailySalesSummaryRepository {
+	return &DailySalesSummaryRepository{
+		Log:      log,
+		Location: location,
+	}
+}
+
+// dailySalesRollup aggregates raw transactions into summary rows per day of
+// the timezone given as its only parameter; it is the single definition shared
+// by the rebuild and the verification.
+const dailySalesRollup = `
+SELECT (t.transaction_at AT TIME ZONE ?)::date AS sales_date,
+       t.product_id,
+       SUM(t.qty) AS qty,
+       SUM(t.total_price) AS revenue,
+       COUNT(*) AS transaction_count
+FROM transactions t
+GROUP BY 1, 2`
+
+// Increment adds a newly created transaction to its day's summary row. It
+// must run in the same database transaction that inserts the transaction.
+func (r *DailySalesSummaryRepository) Increment(db *gorm.DB, transaction *entity.Transaction) error {
+	return db.Exec(`
+INSERT INTO daily_sales_summary (sales_date, product_id, qty, revenue, transaction_count, updated_at)
+VALUES (?, ?, ?, ?, 1, now())
+ON CONFLICT (sales_date, product_id)
+DO UPDATE SET qty = daily_sales_summary.qty + EXCLUDED.qty,
+              revenue = daily_sales_summary.revenue + EXCLUDED.revenue,
+              transaction_count = daily_sales_summary.transaction_count + 1,
+              updated_at = now()
+`,
+		entity.SalesDate(transaction.TransactionAt, r.Location).Format(constants.DateLayout),
+		transaction.ProductID,
+		transaction.Qty,
+		transaction.TotalPrice,
+	).Error
+}
+
+// Rebuild replaces the whole summary with a fresh rollup of transactions and
+// returns the number of rows written. Run it inside a transaction so readers
+// never observe an empty table.
+func (r *DailySalesSummaryRepository) Rebuild(db *gorm.DB) (int64, error) {
+	if err := db.Exec(`DELETE FROM daily_sales_summary`).Error; err != nil {
+		return 0, err
+	}
+
+	result := db.Exec(`
+INSERT INTO daily_sales_summary (sales_date, product_id, qty, revenue, transaction_count, updated_at)
+SELECT sales_date, product_id, qty, revenue, transaction_count, now()
+FROM (`+dailySalesRollup+`
+) rolled`, r.Location.String())
+	return result.RowsAffected, result.Error
+}
+
+// FindMismatches compares the summary against a fresh rollup and returns
+// every day/product pair whose figures differ or exist on one side only.
+func (r *DailySalesSummaryRepository) FindMismatches(db *gorm.DB) ([]SummaryMismatchRow, error) {
+	var rows []SummaryMismatchRow
+	err := db.Raw(`
+SELECT COALESCE(s.sales_date, a.sales_date) AS sales_date,
+       COALESCE(s.product_id, a.product_id) AS product_id,
+       COALESCE(s.qty, 0) AS summary_qty,
+       COALESCE(a.qty, 0) AS actual_qty,
+       COALESCE(s.revenue, 0) AS summary_revenue,
+       COALESCE(a.revenue, 0) AS actual_revenue,
+       COALESCE(s.transaction_count, 0) AS summary_transactions,
+       COALESCE(a.transaction_count, 0) AS actual_transactions
+FROM daily_sales_summary s
+FULL OUTER JOIN (`+dailySalesRollup+`
+) a ON a.sales_date = s.sales_date AND a.product_id = s.product_id
+WHERE s.sales_date IS NULL
+   OR a.sales_date IS NULL
+   OR s.qty <> a.qty
+   OR s.revenue <> a.revenue
+   OR s.transaction_count <> a.transaction_count
+ORDER BY 1, 2
+`, r.Location.String()).Scan(&rows).Error
+	return rows, err
+}
@@ -1,11 +1,50 @@
 package repository
 
 import (
+	"strings"
+	"time"
+
 	"snack-store-api/internal/entity"
 
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
 )
 
+type RedemptionFilter struct {
+	StartDate    time.Time
+	EndDate      time.Time
+	CustomerName string
+	ProductID    *uuid.UUID
+	Size         string
+	SortAsc      bool
+	After        *Keyset
+}
+
+func (f *RedemptionFilter) apply(db *gorm.DB) *gorm.DB {
+	db = db.Where("redemptions.redeem_at >= ? AND redemptions.redeem_at < ?", f.StartDate, f.EndDate)
+
+	if f.CustomerName != "" {
+		db = db.Where("redemptions.customer_id IN (SELECT id FROM customers WHERE lower(name) = ?)", strings.ToLower(f.CustomerName))
+	}
+	if f.ProductID != nil {
+		db = db.Where("redemptions.product_id = ?", *f.ProductID)
+	}
+	if f.Size != "" {
+		db = db.Where("redemptions.product_id IN (SELECT id FROM products WHERE size = ?)", f.Size)
+	}
+
+	return db
+}
+
+func (f *RedemptionFilter) order() string {
+	if f.SortAsc {
+		return "redemptions.redeem_at asc, redemptions.id asc"
+	}
+
+	return "redemptions.redeem_at desc, redemptions.id desc"
+}
+
 type RedemptionRepository struct {
 	Repository[entity.Redemption]
 	Log *logrus.Logger
@@ -16,3 +55,39 @@ func NewRedemptionRepository(log *logrus.Logger) *RedemptionRepository {
 		Log: log,
 	}
 }
+
+func (r *RedemptionRepository) FindByFilter(
+	db *gorm.DB,
+	filter *RedemptionFilter,
+	limit int,
+	offset int,
+) ([]entity.Redemption, error) {
+	var redemptions []entity.Redemption
+	query := filter.apply(db.Preload("Customer").Preload("Product"))
+	if filter.After != nil {
+		query = query.Where(
+			keysetCondition("redemptions.redeem_at", "redemptions.id", filter.SortAsc),
+			filter.After.At,
+			filter.After.ID,
+		)
+	}
+
+	err := query.Order(filter.order()).
+		Limit(limit).
+		Offset(offset).
+		Find(&redemptions).Error
+	return redemptions, err
+}
+
+func (r *RedemptionRepository) CountByFilter(db *gorm.DB, filter *RedemptionFilter) (int64, error) {
+	var total int64
+	err := filter.apply(db.Model(&entity.Redemption{})).Count(&total).Error
+	return total, err
+}
+
+func (r *RedemptionRepository) FindDetailById(db *gorm.DB, redemption *entity.Redemption, id any) error {
+	return db.Preload("Customer").
+		Preload("Product").
+		Where("id = ?", id).
+		Take(redemption).Error
+}
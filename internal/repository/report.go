@@ -1,14 +1,24 @@
 package repository
 
 import (
+	"fmt"
 	"time"
 
+	"snack-store-api/internal/constants"
 	"snack-store-api/internal/entity"
 
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
 )
 
+type TransactionSummaryRow struct {
+	TotalCustomer     int64 `gorm:"column:total_customer"`
+	HasNewCustomer    bool  `gorm:"column:has_new_customer"`
+	TotalIncome       int64 `gorm:"column:total_income"`
+	TotalProductsSold int64 `gorm:"column:total_products_sold"`
+}
+
 type BestSellerRow struct {
 	ProductName string `gorm:"column:product_name"`
 	Size        string `gorm:"column:size"`
@@ -16,12 +26,155 @@ type BestSellerRow struct {
 	TotalQty    int    `gorm:"column:total_qty"`
 }
 
+type BreakdownRow struct {
+	GroupKey         string `gorm:"column:group_key"`
+	ProductName      string `gorm:"column:product_name"`
+	Size             string `gorm:"column:size"`
+	Flavor           string `gorm:"column:flavor"`
+	Type             string `gorm:"column:type"`
+	TotalQty         int64  `gorm:"column:total_qty"`
+	Revenue          int64  `gorm:"column:revenue"`
+	TotalTransaction int64  `gorm:"column:total_transaction"`
+	Rank             int    `gorm:"column:rank"`
+	AllQty           int64  `gorm:"column:all_qty"`
+	AllRevenue       int64  `gorm:"column:all_revenue"`
+}
+
+type breakdownDimension struct {
+	columns string
+	groupBy string
+}
+
+// breakdownDimensions whitelists the SQL fragments per group_by value; only
+// these are ever interpolated into the breakdown query.
+var breakdownDimensions = map[string]breakdownDimension{
+	constants.BreakdownFlavor: {
+		columns: "p.flavor AS group_key, '' AS product_name, '' AS size, p.flavor AS flavor, '' AS type",
+		groupBy: "p.flavor",
+	},
+	constants.BreakdownSize: {
+		columns: "p.size AS group_key, '' AS product_name, p.size AS size, '' AS flavor, '' AS type",
+		groupBy: "p.size",
+	},
+	constants.BreakdownType: {
+		columns: "p.type AS group_key, '' AS product_name, '' AS size, '' AS flavor, p.type AS type",
+		groupBy: "p.type",
+	},
+	constants.BreakdownProduct: {
+		columns: "CAST(p.id AS text) AS group_key, p.name AS product_name, p.size, p.flavor, p.type",
+		groupBy: "p.id, p.name, p.size, p.flavor, p.type",
+	},
+}
+
+// salesSource returns a subquery exposing product_id, qty, total_price and
+// transaction_count for [startDate, endDate). Ranges of whole store-local days
+// read the pre-aggregated daily_sales_summary; anything else falls back to the
+// raw transactions.
+func (r *ReportRepository) salesSource(startDate, endDate time.Time) (string, []any) {
+	if entity.IsWholeDayRange(startDate, endDate, r.Location) {
+		return `(
+  SELECT product_id, qty, revenue AS total_price, transaction_count
+  FROM daily_sales_summary
+  WHERE sales_date >= ? AND sales_date < ?
+)`, []any{
+			startDate.In(r.Location).Format(constants.DateLayout),
+			endDate.In(r.Location).Format(constants.DateLayout),
+		}
+	}
+
+	return `(
+  SELECT product_id, qty, total_price, 1 AS transaction_count
+  FROM transactions
+  WHERE transaction_at >= ? AND transaction_at < ?
+)`, []any{startDate, endDate}
+}
+
+type CohortActivityRow struct {
+	CohortMonth    time.Time `gorm:"column:cohort_month"`
+	CohortSize     int64     `gorm:"column:cohort_size"`
+	MonthOffset    *int      `gorm:"column:month_offset"`
+	ActiveCustomer int64     `gorm:"column:active_customer"`
+}
+
+type CohortSummaryRow struct {
+	TotalCustomer        int64   `gorm:"column:total_customer"`
+	RepeatCustomer       int64   `gorm:"column:repeat_customer"`
+	AvgDaysBetweenVisits float64 `gorm:"column:avg_days_between_visits"`
+	ChurnedCustomer      int64   `gorm:"column:churned_customer"`
+}
+
+type PointsBucketRow struct {
+	Period         time.Time `gorm:"column:period"`
+	PointsEarned   int64     `gorm:"column:points_earned"`
+	PointsRedeemed int64     `gorm:"column:points_redeemed"`
+}
+
+type OutstandingPointsRow struct {
+	TotalPoints         int64 `gorm:"column:total_points"`
+	CustomersWithPoints int64 `gorm:"column:customers_with_points"`
+}
+
+type RedemptionValueRow struct {
+	PointsSpent       int64 `gorm:"column:points_spent"`
+	ItemValue         int64 `gorm:"column:item_value"`
+	CostedPointsSpent int64 `gorm:"column:costed_points_spent"`
+	ItemCost          int64 `gorm:"column:item_cost"`
+}
+
+type TopRedeemedRow struct {
+	ProductName     string `gorm:"column:product_name"`
+	Size            string `gorm:"column:size"`
+	Flavor          string `gorm:"column:flavor"`
+	TotalQty        int64  `gorm:"column:total_qty"`
+	PointsSpent     int64  `gorm:"column:points_spent"`
+	TotalRedemption int64  `gorm:"column:total_redemption"`
+}
+
+type InventoryRow struct {
+	ProductID        uuid.UUID  `gorm:"column:product_id"`
+	Name             string     `gorm:"column:name"`
+	Type             string     `gorm:"column:type"`
+	Flavor           string     `gorm:"column:flavor"`
+	Size             string     `gorm:"column:size"`
+	ManufacturedDate time.Time  `gorm:"column:manufactured_date"`
+	StockQty         int64      `gorm:"column:stock_qty"`
+	Price            int64      `gorm:"column:price"`
+	CostPrice        *int64     `gorm:"column:cost_price"`
+	RecentQty        int64      `gorm:"column:recent_qty"`
+	LastSoldAt       *time.Time `gorm:"column:last_sold_at"`
+}
+
+type HeatmapRow struct {
+	IsoWeekday       int   `gorm:"column:iso_weekday"`
+	Hour             int   `gorm:"column:hour"`
+	TotalTransaction int64 `gorm:"column:total_transaction"`
+	Revenue          int64 `gorm:"column:revenue"`
+}
+
+type TaxSummaryRow struct {
+	Period           time.Time `gorm:"column:period"`
+	TaxRateBps       int       `gorm:"column:tax_rate_bps"`
+	TotalTransaction int64     `gorm:"column:total_transaction"`
+	NetAmount        int64     `gorm:"column:net_amount"`
+	TaxAmount        int64     `gorm:"column:tax_amount"`
+	GrossAmount      int64     `gorm:"column:gross_amount"`
+}
+
+type SalesBucketRow struct {
+	Period           time.Time `gorm:"column:period"`
+	Income           int64     `gorm:"column:income"`
+	QtySold          int64     `gorm:"column:qty_sold"`
+	TotalTransaction int64     `gorm:"column:total_transaction"`
+	TotalCustomer    int64     `gorm:"column:total_customer"`
+}
+
 type ReportRepository struct {
-	Log *logrus.Logger
+	Log      *logrus.Logger
+	Location *time.Location
 }
 
-func NewReportRepository(log *logrus.Logger) *ReportRepository {
-	return &ReportRepository{Log: log}
+func NewReportRepository(log *logrus.Logger, location *time.Location) *ReportRepository {
+	return &ReportRepository{Log: log, Location: location}
 }
 
 func (r *ReportRepository) GetTotalCustomer(db *gorm.DB, startDate, endDate time.Time) (int64, error) {
@@ -33,57 +186,104 @@ func (r *ReportRepository) GetTotalCustomer(db *gorm.DB, startDate, endDate time
 	return total, err
 }
 
-func (r *ReportRepository) HasNewCustomer(db *gorm.DB, startDate, endDate time.Time) (bool, error) {
-	var exists bool
+// GetTransactionSummary computes the headline aggregates of the transactions
+// report in a single statement, so every figure comes from the same snapshot.
+// Income and products sold come from salesSource; distinct customers cannot be
+// summed from per-product rows, so the customer figures always read the raw
+// transactions.
+func (r *ReportRepository) GetTransactionSummary(db *gorm.DB, startDate, endDate time.Time) (*TransactionSummaryRow, error) {
+	var row TransactionSummaryRow
+	source, args := r.salesSource(startDate, endDate)
 	err := db.Raw(`
-SELECT EXISTS (
-  SELECT 1
+SELECT customers.total_customer,
+       customers.has_new_customer,
+       sales.total_income,
+       sales.total_products_sold
+FROM (
+  SELECT COUNT(DISTINCT t.customer_id) AS total_customer,
+         COALESCE(BOOL_OR(date_trunc('month', c.created_at) = date_trunc('month', t.transaction_at)), FALSE) AS has_new_customer
   FROM transactions t
   JOIN customers c ON c.id = t.customer_id
   WHERE t.transaction_at >= ? AND t.transaction_at < ?
-    AND date_trunc('month', c.created_at) = date_trunc('month', t.transaction_at)
-)`, startDate, endDate).Scan(&exists).Error
-	return exists, err
+) customers
+CROSS JOIN (
+  SELECT COALESCE(SUM(s.total_price), 0) AS total_income,
+         COALESCE(SUM(s.qty), 0) AS total_products_sold
+  FROM `+source+` s
+) sales
+`, append([]any{startDate, endDate}, args...)...).Scan(&row).Error
+	if err != nil {
+		return nil, err
+	}
+	return &row, nil
 }
 
-func (r *ReportRepository) GetTotalIncome(db *gorm.DB, startDate, endDate time.Time) (int, error) {
-	var total int64
-	err := db.Model(&entity.Transaction{}).
-		Select("COALESCE(SUM(total_price), 0)").
-		Where("transaction_at >= ? AND transaction_at < ?", startDate, endDate).
-		Scan(&total).Error
-	return int(total), err
-}
+// GetBestSellers returns every product sharing the highest total qty in the
+// period, ordered by name so the first row is stable across calls.
+func (r *ReportRepository) GetBestSellers(db *gorm.DB, startDate, endDate time.Time) ([]BestSellerRow, error) {
+	source, args := r.salesSource(startDate, endDate)
 
-func (r *ReportRepository) GetTotalProductsSold(db *gorm.DB, startDate, endDate time.Time) (int, error) {
-	var total int64
-	err := db.Model(&entity.Transaction{}).
-		Select("COALESCE(SUM(qty), 0)").
-		Where("transaction_at >= ? AND transaction_at < ?", startDate, endDate).
-		Scan(&total).Error
-	return int(total), err
+	var rows []BestSellerRow
+	err := db.Raw(`
+SELECT product_name, size, flavor, total_qty
+FROM (
+  SELECT p.name AS product_name, p.size, p.flavor, SUM(t.qty) AS total_qty,
+         RANK() OVER (ORDER BY SUM(t.qty) DESC) AS qty_rank
+  FROM `+source+` t
+  JOIN products p ON p.id = t.product_id
+  GROUP BY p.id, p.name, p.size, p.flavor
+) ranked
+WHERE qty_rank = 1
+ORDER BY product_name, size, flavor
+`, args...).Scan(&rows).Error
+	return rows, err
 }
 
-func (r *ReportRepository) GetBestSeller(db *gorm.DB, startDate, endDate time.Time) (*BestSellerRow, error) {
-	var row BestSellerRow
-	err := db.Raw(`
-SELECT p.name AS product_name, p.size, p.flavor, SUM(t.qty) AS total_qty
-FROM transactions t
-JOIN products p ON p.id = t.product_id
-WHERE t.transaction_at >= ? AND t.transaction_at < ?
-GROUP BY p.id, p.name, p.size, p.flavor
-ORDER BY total_qty DESC
-LIMIT 1
-`, startDate, endDate).Scan(&row).Error
-	if err != nil {
-		return nil, err
+// GetSalesBreakdown groups sales by one product dimension and ranks the groups
+// by revenue or qty. Groups tied with the N-th rank are all kept, so the result
+// can hold more than limit rows.
+func (r *ReportRepository) GetSalesBreakdown(
+	db *gorm.DB,
+	startDate time.Time,
+	endDate time.Time,
+	groupBy string,
+	sortBy string,
+	limit int,
+) ([]BreakdownRow, error) {
+	dimension, ok := breakdownDimensions[groupBy]
+	if !ok {
+		return nil, fmt.Errorf("unsupported breakdown dimension %q", groupBy)
 	}
 
-	if row.ProductName == "" && row.TotalQty == 0 {
-		return nil, nil
+	metric := "revenue"
+	if sortBy == constants.BreakdownSortQty {
+		metric = "total_qty"
 	}
 
-	return &row, nil
+	source, args := r.salesSource(startDate, endDate)
+
+	var rows []BreakdownRow
+	err := db.Raw(`
+SELECT *
+FROM (
+  SELECT grouped.*,
+         RANK() OVER (ORDER BY `+metric+` DESC) AS rank,
+         SUM(grouped.total_qty) OVER () AS all_qty,
+         SUM(grouped.revenue) OVER () AS all_revenue
+  FROM (
+    SELECT `+dimension.columns+`,
+           SUM(t.qty) AS total_qty,
+           SUM(t.total_price) AS revenue,
+           SUM(t.transaction_count) AS total_transaction
+    FROM `+source+` t
+    JOIN products p ON p.id = t.product_id
+    GROUP BY `+dimension.groupBy+`
+  ) grouped
+) ranked
+WHERE rank <= ?
+ORDER BY rank, group_key
+`, append(args, limit)...).Scan(&rows).Error
+	return rows, err
 }
 
 func (r *ReportRepository) GetLastTransactions(
@@ -101,3 +301,315 @@ func (r *ReportRepository) GetLastTransactions(
 		Find(&transactions).Error
 	return transactions, err
 }
+
+// GetTaxSummary totals tax per rate and calendar month in the store timezone.
+func (r *ReportRepository) GetTaxSummary(db *gorm.DB, startDate, endDate time.Time) ([]TaxSummaryRow, error) {
+	var rows []TaxSummaryRow
+	err := db.Raw(`
+SELECT date_trunc('month', t.transaction_at AT TIME ZONE ?) AS period,
+       t.tax_rate_bps,
+       COUNT(*) AS total_transaction,
+       COALESCE(SUM(t.net_amount), 0) AS net_amount,
+       COALESCE(SUM(t.tax_amount), 0) AS tax_amount,
+       COALESCE(SUM(t.total_price), 0) AS gross_amount
+FROM transactions t
+WHERE t.transaction_at >= ? AND t.transaction_at < ?
+GROUP BY period, t.tax_rate_bps
+ORDER BY period, t.tax_rate_bps
+`, r.Location.String(), startDate, endDate).Scan(&rows).Error
+	return rows, err
+}
+
+// GetSalesSeries aggregates sales per day, week (ISO, starting Monday) or month
+// bucket, with buckets taken in the given IANA timezone. Period is the local
+// wall-clock bucket start; only buckets with sales are returned.
+func (r *ReportRepository) GetSalesSeries(
+	db *gorm.DB,
+	startDate time.Time,
+	endDate time.Time,
+	granularity string,
+	timezone string,
+) ([]SalesBucketRow, error) {
+	var rows []SalesBucketRow
+	err := db.Raw(`
+SELECT date_trunc(?, t.transaction_at AT TIME ZONE ?) AS period,
+       SUM(t.total_price) AS income,
+       SUM(t.qty) AS qty_sold,
+       COUNT(*) AS total_transaction,
+       COUNT(DISTINCT t.customer_id) AS total_customer
+FROM transactions t
+WHERE t.transaction_at >= ? AND t.transaction_at < ?
+GROUP BY 1
+ORDER BY 1
+`, granularity, timezone, startDate, endDate).Scan(&rows).Error
+	return rows, err
+}
+
+// bucketsCTE generates every wall-clock day, week or month bucket of a range
+// so reports can LEFT JOIN their aggregates onto it. Its parameters come from
+// bucketArgs.
+const bucketsCTE = `
+buckets AS (
+  SELECT generate_series(
+    date_trunc(?, CAST(? AS timestamp)),
+    CAST(? AS timestamp),
+    CAST(? AS interval)
+  ) AS period
+)`
+
+func bucketArgs(startDate, endDate time.Time, granularity string) []interface{} {
+	return []interface{}{
+		granularity,
+		startDate.Format(constants.DateLayout),
+		endDate.AddDate(0, 0, -1).Format(constants.DateLayout),
+		"1 " + granularity,
+	}
+}
+
+// cohortCTE selects the customers created in [start, end) together with their
+// signup month in the store timezone. It takes the timezone and the two range
+// bounds as parameters.
+const cohortCTE = `
+cohort AS (
+  SELECT id AS customer_id,
+         created_at,
+         date_trunc('month', created_at AT TIME ZONE ?) AS cohort_month
+  FROM customers
+  WHERE created_at >= ? AND created_at < ?
+)`
+
+// GetCohortActivity returns, per signup month, the cohort size and how many of
+// its customers bought N months after signing up, for N up to maxOffset.
+// Cohorts without any purchase yet come back as a single row with a NULL
+// offset, so they still count. Purchases dated before the signup month are
+// ignored.
+func (r *ReportRepository) GetCohortActivity(
+	db *gorm.DB,
+	startDate time.Time,
+	endDate time.Time,
+	maxOffset int,
+) ([]CohortActivityRow, error) {
+	var rows []CohortActivityRow
+	err := db.Raw(`
+WITH `+cohortCTE+`,
+sizes AS (
+  SELECT cohort_month, COUNT(*) AS cohort_size
+  FROM cohort
+  GROUP BY cohort_month
+),
+activity AS (
+  SELECT DISTINCT c.cohort_month, c.customer_id,
+         CAST(
+           (EXTRACT(YEAR FROM m.month) - EXTRACT(YEAR FROM c.cohort_month)) * 12 +
+           (EXTRACT(MONTH FROM m.month) - EXTRACT(MONTH FROM c.cohort_month))
+         AS integer) AS month_offset
+  FROM cohort c
+  JOIN transactions t ON t.customer_id = c.customer_id
+  CROSS JOIN LATERAL (SELECT date_trunc('month', t.transaction_at AT TIME ZONE ?) AS month) m
+)
+SELECT s.cohort_month, s.cohort_size, a.month_offset, COUNT(a.customer_id) AS active_customer
+FROM sizes s
+LEFT JOIN activity a
+  ON a.cohort_month = s.cohort_month
+ AND a.month_offset BETWEEN 0 AND ?
+GROUP BY s.cohort_month, s.cohort_size, a.month_offset
+ORDER BY s.cohort_month, a.month_offset
+`, r.Location.String(), startDate, endDate, r.Location.String(), maxOffset).Scan(&rows).Error
+	return rows, err
+}
+
+// GetCohortSummary computes repeat and churn figures for the customers created
+// in the range, looking only at visits before the end of the range so the
+// figures do not drift as time passes. A visit is a distinct store-local day
+// with at least one transaction, so several products bought together count
+// once. A customer is churned when their last visit, or their signup if they
+// never bought, is before churnedBefore.
+func (r *ReportRepository) GetCohortSummary(
+	db *gorm.DB,
+	startDate time.Time,
+	endDate time.Time,
+	churnedBefore time.Time,
+) (*CohortSummaryRow, error) {
+	var row CohortSummaryRow
+	err := db.Raw(`
+WITH `+cohortCTE+`,
+visits AS (
+  SELECT DISTINCT t.customer_id, CAST(t.transaction_at AT TIME ZONE ? AS date) AS visit_date
+  FROM transactions t
+  JOIN cohort c ON c.customer_id = t.customer_id
+  WHERE t.transaction_at < ?
+),
+gaps AS (
+  SELECT customer_id, visit_date,
+         visit_date - LAG(visit_date) OVER (PARTITION BY customer_id ORDER BY visit_date) AS gap_days
+  FROM visits
+),
+per_customer AS (
+  SELECT c.customer_id,
+         COUNT(v.visit_date) AS visit_count,
+         COALESCE(MAX(v.visit_date), CAST(c.created_at AT TIME ZONE ? AS date)) AS last_seen
+  FROM cohort c
+  LEFT JOIN visits v ON v.customer_id = c.customer_id
+  GROUP BY c.customer_id, c.created_at
+)
+SELECT
+  (SELECT COUNT(*) FROM per_customer) AS total_customer,
+  (SELECT COUNT(*) FROM per_customer WHERE visit_count > 1) AS repeat_customer,
+  (SELECT CAST(COALESCE(AVG(gap_days), 0) AS double precision) FROM gaps WHERE gap_days IS NOT NULL) AS avg_days_between_visits,
+  (SELECT COUNT(*) FROM per_customer WHERE last_seen < CAST(? AS date)) AS churned_customer
+`,
+		r.Location.String(), startDate, endDate,
+		r.Location.String(), endDate,
+		r.Location.String(),
+		churnedBefore.Format(constants.DateLayout),
+	).Scan(&row).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &row, nil
+}
+
+// GetPointsSeries returns points earned by transactions and spent on
+// redemptions per bucket in the store timezone, zero-filled through
+// bucketsCTE.
+func (r *ReportRepository) GetPointsSeries(
+	db *gorm.DB,
+	startDate time.Time,
+	endDate time.Time,
+	granularity string,
+) ([]PointsBucketRow, error) {
+	var rows []PointsBucketRow
+	timezone := r.Location.String()
+	args := append(
+		bucketArgs(startDate, endDate, granularity),
+		granularity, timezone, startDate, endDate,
+		granularity, timezone, startDate, endDate,
+	)
+	err := db.Raw(`
+WITH `+bucketsCTE+`,
+earned AS (
+  SELECT date_trunc(?, transaction_at AT TIME ZONE ?) AS period, SUM(points_earned) AS points
+  FROM transactions
+  WHERE transaction_at >= ? AND transaction_at < ?
+  GROUP BY 1
+),
+redeemed AS (
+  SELECT date_trunc(?, redeem_at AT TIME ZONE ?) AS period, SUM(points_spent) AS points
+  FROM redemptions
+  WHERE redeem_at >= ? AND redeem_at < ?
+  GROUP BY 1
+)
+SELECT b.period,
+       COALESCE(e.points, 0) AS points_earned,
+       COALESCE(r.points, 0) AS points_redeemed
+FROM buckets b
+LEFT JOIN earned e ON e.period = b.period
+LEFT JOIN redeemed r ON r.period = b.period
+ORDER BY b.period
+`, args...).Scan(&rows).Error
+	return rows, err
+}
+
+func (r *ReportRepository) GetOutstandingPoints(db *gorm.DB) (*OutstandingPointsRow, error) {
+	var row OutstandingPointsRow
+	err := db.Model(&entity.Customer{}).
+		Select("COALESCE(SUM(points), 0) AS total_points, COUNT(*) FILTER (WHERE points > 0) AS customers_with_points").
+		Scan(&row).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &row, nil
+}
+
+// GetRedemptionValue sums points spent against the current price and cost of
+// the redeemed items over all redemptions. The cost side only covers
+// redemptions of products with a cost_price, so it carries its own points
+// total.
+func (r *ReportRepository) GetRedemptionValue(db *gorm.DB) (*RedemptionValueRow, error) {
+	var row RedemptionValueRow
+	err := db.Raw(`
+SELECT COALESCE(SUM(r.points_spent), 0) AS points_spent,
+       COALESCE(SUM(p.price * r.qty), 0) AS item_value,
+       COALESCE(SUM(r.points_spent) FILTER (WHERE p.cost_price IS NOT NULL), 0) AS costed_points_spent,
+       COALESCE(SUM(p.cost_price * r.qty), 0) AS item_cost
+FROM redemptions r
+JOIN products p ON p.id = r.product_id
+`).Scan(&row).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &row, nil
+}
+
+func (r *ReportRepository) GetTopRedeemedProducts(
+	db *gorm.DB,
+	startDate time.Time,
+	endDate time.Time,
+	limit int,
+) ([]TopRedeemedRow, error) {
+	var rows []TopRedeemedRow
+	err := db.Raw(`
+SELECT p.name AS product_name, p.size, p.flavor,
+       SUM(r.qty) AS total_qty,
+       SUM(r.points_spent) AS points_spent,
+       COUNT(*) AS total_redemption
+FROM redemptions r
+JOIN products p ON p.id = r.product_id
+WHERE r.redeem_at >= ? AND r.redeem_at < ?
+GROUP BY p.id, p.name, p.size, p.flavor
+ORDER BY total_qty DESC, p.name, p.size, p.flavor
+LIMIT ?
+`, startDate, endDate, limit).Scan(&rows).Error
+	return rows, err
+}
+
+// GetInventory lists products with stock on hand together with the qty sold
+// since salesSince and the time they were last sold.
+func (r *ReportRepository) GetInventory(db *gorm.DB, salesSince time.Time) ([]InventoryRow, error) {
+	var rows []InventoryRow
+	err := db.Raw(`
+SELECT p.id AS product_id, p.name, p.type, p.flavor, p.size, p.manufactured_date,
+       p.stock_qty, p.price, p.cost_price,
+       COALESCE(s.recent_qty, 0) AS recent_qty,
+       s.last_sold_at
+FROM products p
+LEFT JOIN (
+  SELECT product_id,
+         SUM(qty) FILTER (WHERE transaction_at >= ?) AS recent_qty,
+         MAX(transaction_at) AS last_sold_at
+  FROM transactions
+  GROUP BY product_id
+) s ON s.product_id = p.id
+WHERE p.stock_qty > 0
+ORDER BY p.manufactured_date, p.name, p.size, p.flavor
+`, salesSince).Scan(&rows).Error
+	return rows, err
+}
+
+// GetHourlyHeatmap counts transactions and revenue per ISO weekday and hour of
+// day, both taken in the given IANA timezone.
+func (r *ReportRepository) GetHourlyHeatmap(
+	db *gorm.DB,
+	startDate time.Time,
+	endDate time.Time,
+	timezone string,
+) ([]HeatmapRow, error) {
+	var rows []HeatmapRow
+	err := db.Raw(`
+SELECT CAST(EXTRACT(ISODOW FROM local_at) AS integer) AS iso_weekday,
+       CAST(EXTRACT(HOUR FROM local_at) AS integer) AS hour,
+       COUNT(*) AS total_transaction,
+       SUM(total_price) AS revenue
+FROM (
+  SELECT transaction_at AT TIME ZONE ? AS local_at, total_price
+  FROM transactions
+  WHERE transaction_at >= ? AND transaction_at < ?
+) t
+GROUP BY 1, 2
+ORDER BY 1, 2
+`, timezone, startDate, endDate).Scan(&rows).Error
+	return rows, err
+}
@@ -1,6 +1,28 @@
 package repository
 
-import "gorm.io/gorm"
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Keyset is the (timestamp, id) position of the last row of the previous page.
+type Keyset struct {
+	At time.Time
+	ID uuid.UUID
+}
+
+// keysetCondition returns the row-value comparison that continues after the keyset
+// in the given sort direction.
+func keysetCondition(atColumn, idColumn string, sortAsc bool) string {
+	if sortAsc {
+		return "(" + atColumn + ", " + idColumn + ") > (?, ?)"
+	}
+
+	return "(" + atColumn + ", " + idColumn + ") < (?, ?)"
+}
 
 type Repository[T any] struct {
 	DB *gorm.DB
@@ -10,6 +32,35 @@ func (r *Repository[T]) Create(db *gorm.DB, entity *T) error {
 	return db.Create(entity).Error
 }
 
+func (r *Repository[T]) CreateInBatches(db *gorm.DB, entities []T, batchSize int) error {
+	if len(entities) == 0 {
+		return nil
+	}
+	return db.Omit(clause.Associations).CreateInBatches(entities, batchSize).Error
+}
+
+// Each streams every row in order through fn using a database cursor.
+func (r *Repository[T]) Each(db *gorm.DB, order string, fn func(entity *T) error) error {
+	query := db.Model(new(T)).Order(order)
+	rows, err := query.Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var entity T
+		if err := query.ScanRows(rows, &entity); err != nil {
+			return err
+		}
+		if err := fn(&entity); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
 func (r *Repository[T]) Update(db *gorm.DB, entity *T) error {
 	return db.Save(entity).Error
 }
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"snack-store-api/internal/entity"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ShiftSalesRow struct {
+	TotalTransaction int64 `gorm:"column:total_transaction"`
+	TotalQty         int64 `gorm:"column:total_qty"`
+	TotalSales       int64 `gorm:"column:total_sales"`
+	TotalTax         int64 `gorm:"column:total_tax"`
+}
+
+type ShiftRedemptionRow struct {
+	TotalRedemption  int64 `gorm:"column:total_redemption"`
+	TotalQty         int64 `gorm:"column:total_qty"`
+	TotalPointsSpent int64 `gorm:"column:total_points_spent"`
+}
+
+type ShiftRepository struct {
+	Repository[entity.Shift]
+	Log *logrus.Logger
+}
+
+func NewShiftRepository(log *logrus.Logger) *ShiftRepository {
+	return &ShiftRepository{
+		Log: log,
+	}
+}
+
+// FindOpen returns the open shifts of cashierName, or of any cashier when it
+// is empty. At most two are returned, which is enough for callers to tell a
+// single open shift from an ambiguous choice.
+func (r *ShiftRepository) FindOpen(db *gorm.DB, cashierName string, lockStrength string) ([]entity.Shift, error) {
+	query := db.Where("status = ?", entity.ShiftStatusOpen)
+	if cashierName != "" {
+		query = query.Where("cashier_name = ?", cashierName)
+	}
+	if lockStrength != "" {
+		query = query.Clauses(clause.Locking{Strength: lockStrength})
+	}
+
+	var shifts []entity.Shift
+	err := query.Order("opened_at, id").Limit(2).Find(&shifts).Error
+	return shifts, err
+}
+
+func (r *ShiftRepository) GetSalesSummary(db *gorm.DB, shiftID uuid.UUID) (ShiftSalesRow, error) {
+	var row ShiftSalesRow
+	err := db.Model(&entity.Transaction{}).
+		Select(`COUNT(*) AS total_transaction,
+COALESCE(SUM(qty), 0) AS total_qty,
+COALESCE(SUM(total_price), 0) AS total_sales,
+COALESCE(SUM(tax_amount), 0) AS total_tax`).
+		Where("shift_id = ?", shiftID).
+		Scan(&row).Error
+	return row, err
+}
+
+func (r *ShiftRepository) GetRedemptionSummary(db *gorm.DB, shiftID uuid.UUID) (ShiftRedemptionRow, error) {
+	var row ShiftRedemptionRow
+	err := db.Model(&entity.Redemption{}).
+		Select(`COUNT(*) AS total_redemption,
+COALESCE(SUM(qty), 0) AS total_qty,
+COALESCE(SUM(points_spent), 0) AS total_points_spent`).
+		Where("shift_id = ?", shiftID).
+		Scan(&row).Error
+	return row, err
+}
@@ -1,14 +1,85 @@
 package repository
 
 import (
+	"strings"
 	"time"
 
+	"snack-store-api/internal/constants"
 	"snack-store-api/internal/entity"
 
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
 )
 
+type TransactionFilter struct {
+	StartDate    time.Time
+	EndDate      time.Time
+	CustomerName string
+	ProductID    *uuid.UUID
+	Flavor       string
+	Size         string
+	MinTotal     *int
+	MaxTotal     *int
+	SortAsc      bool
+	After        *Keyset
+}
+
+func (f *TransactionFilter) apply(db *gorm.DB) *gorm.DB {
+	db = db.Where("transactions.transaction_at >= ? AND transactions.transaction_at < ?", f.StartDate, f.EndDate)
+
+	if f.CustomerName != "" {
+		db = db.Where("transactions.customer_id IN (SELECT id FROM customers WHERE lower(name) = ?)", strings.ToLower(f.CustomerName))
+	}
+	if f.ProductID != nil {
+		db = db.Where("transactions.product_id = ?", *f.ProductID)
+	}
+	if f.Flavor != "" {
+		db = db.Where("transactions.product_id IN (SELECT id FROM products WHERE flavor = ?)", f.Flavor)
+	}
+	if f.Size != "" {
+		db = db.Where("transactions.product_id IN (SELECT id FROM products WHERE size = ?)", f.Size)
+	}
+	if f.MinTotal != nil {
+		db = db.Where("transactions.total_price >= ?", *f.MinTotal)
+	}
+	if f.MaxTotal != nil {
+		db = db.Where("transactions.total_price <= ?", *f.MaxTotal)
+	}
+
+	return db
+}
+
+func (f *TransactionFilter) order() string {
+	if f.SortAsc {
+		return "transactions.transaction_at asc, transactions.id asc"
+	}
+
+	return "transactions.transaction_at desc, transactions.id desc"
+}
+
+type TransactionExportRow struct {
+	ID            uuid.UUID `gorm:"column:id"`
+	ReceiptNo     *string   `gorm:"column:receipt_no"`
+	TransactionAt time.Time `gorm:"column:transaction_at"`
+	CustomerName  string    `gorm:"column:customer_name"`
+	ProductName   string    `gorm:"column:product_name"`
+	Size          string    `gorm:"column:size"`
+	Flavor        string    `gorm:"column:flavor"`
+	Qty           int       `gorm:"column:qty"`
+	UnitPrice     int       `gorm:"column:unit_price"`
+	NetAmount     int       `gorm:"column:net_amount"`
+	TaxAmount     int       `gorm:"column:tax_amount"`
+	TotalPrice    int       `gorm:"column:total_price"`
+	PointsEarned  int       `gorm:"column:points_earned"`
+}
+
+type TaxBackfillRow struct {
+	ProductType  string `gorm:"column:product_type"`
+	Transactions int64  `gorm:"column:transactions"`
+	TotalPrice   int64  `gorm:"column:total_price"`
+}
+
 type TransactionRepository struct {
 	Repository[entity.Transaction]
 	Log *logrus.Logger
@@ -20,32 +91,125 @@ func NewTransactionRepository(log *logrus.Logger) *TransactionRepository {
 	}
 }
 
-func (r *TransactionRepository) FindByDateRange(
+func (r *TransactionRepository) FindByFilter(
 	db *gorm.DB,
-	startDate time.Time,
-	endDate time.Time,
+	filter *TransactionFilter,
 	limit int,
 	offset int,
 ) ([]entity.Transaction, error) {
 	var transactions []entity.Transaction
-	err := db.Preload("Customer").
-		Preload("Product").
-		Where("transaction_at >= ? AND transaction_at < ?", startDate, endDate).
-		Order("transaction_at desc").
+	query := filter.apply(db.Preload("Customer").Preload("Product"))
+	if filter.After != nil {
+		query = query.Where(
+			keysetCondition("transactions.transaction_at", "transactions.id", filter.SortAsc),
+			filter.After.At,
+			filter.After.ID,
+		)
+	}
+
+	err := query.Order(filter.order()).
 		Limit(limit).
 		Offset(offset).
 		Find(&transactions).Error
 	return transactions, err
 }
 
-func (r *TransactionRepository) CountByDateRange(
+// StreamByFilter walks the filtered transactions with a database cursor and
+// calls fn for each row, so exports never hold the full result in memory.
+func (r *TransactionRepository) StreamByFilter(
 	db *gorm.DB,
-	startDate time.Time,
-	endDate time.Time,
-) (int64, error) {
+	filter *TransactionFilter,
+	fn func(row *TransactionExportRow) error,
+) error {
+	query := filter.apply(db.Table("transactions").
+		Select(`transactions.id, transactions.receipt_no, transactions.transaction_at,
+			c.name AS customer_name, p.name AS product_name, p.size, p.flavor,
+			transactions.qty, transactions.unit_price, transactions.net_amount,
+			transactions.tax_amount, transactions.total_price, transactions.points_earned`).
+		Joins("JOIN customers c ON c.id = transactions.customer_id").
+		Joins("JOIN products p ON p.id = transactions.product_id")).
+		Order(filter.order())
+
+	rows, err := query.Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row TransactionExportRow
+		if err := query.ScanRows(rows, &row); err != nil {
+			return err
+		}
+		if err := fn(&row); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func (r *TransactionRepository) CountByFilter(db *gorm.DB, filter *TransactionFilter) (int64, error) {
 	var total int64
-	err := db.Model(&entity.Transaction{}).
-		Where("transaction_at >= ? AND transaction_at < ?", startDate, endDate).
-		Count(&total).Error
+	err := filter.apply(db.Model(&entity.Transaction{})).Count(&total).Error
 	return total, err
 }
+
+func (r *TransactionRepository) FindDetailById(db *gorm.DB, transaction *entity.Transaction, id any) error {
+	return db.Preload("Customer").
+		Preload("Product").
+		Where("id = ?", id).
+		Take(transaction).Error
+}
+
+func (r *TransactionRepository) NextReceiptSequence(db *gorm.DB, receiptDate time.Time) (int, error) {
+	var sequence int
+	err := db.Raw(`
+INSERT INTO receipt_sequences (receipt_date, last_number, updated_at)
+VALUES (?, 1, now())
+ON CONFLICT (receipt_date)
+DO UPDATE SET last_number = receipt_sequences.last_number + 1, updated_at = now()
+RETURNING last_number
+`, receiptDate.Format(constants.DateLayout)).Scan(&sequence).Error
+	return sequence, err
+}
+
+// FindTaxBackfill groups the transactions recorded before the tax columns
+// were filled (net_amount = 0 with a positive total_price) by normalized
+// product type.
+func (r *TransactionRepository) FindTaxBackfill(db *gorm.DB) ([]TaxBackfillRow, error) {
+	var rows []TaxBackfillRow
+	err := db.Raw(`
+SELECT lower(trim(p.type)) AS product_type,
+       COUNT(*) AS transactions,
+       COALESCE(SUM(t.total_price), 0) AS total_price
+FROM transactions t
+JOIN products p ON p.id = t.product_id
+WHERE t.net_amount = 0
+  AND t.total_price > 0
+GROUP BY 1
+ORDER BY 1
+`).Scan(&rows).Error
+	return rows, err
+}
+
+// BackfillTax splits the total_price of unfilled transactions of one product
+// type into net and tax, treating total_price as tax-inclusive gross. The
+// rounding matches entity.CalculateTax: net = round(total * 10000 /
+// (10000 + rate)) and tax = total - net.
+func (r *TransactionRepository) BackfillTax(db *gorm.DB, productType string, rateBps int) (int64, error) {
+	result := db.Exec(`
+UPDATE transactions t
+SET net_amount = split.net,
+    tax_amount = t.total_price - split.net,
+    tax_rate_bps = ?
+FROM products p,
+     LATERAL (
+       SELECT ((t.total_price::bigint * 10000 + (10000 + ?) / 2) / (10000 + ?))::integer AS net
+     ) split
+WHERE p.id = t.product_id
+  AND lower(trim(p.type)) = ?
+  AND t.net_amount = 0
+  AND t.total_price > 0`, rateBps, rateBps, rateBps, productType)
+	return result.RowsAffected, result.Error
+}
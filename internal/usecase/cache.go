@@ -0,0 +1,137 @@
+package usecase
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"snack-store-api/internal/cache"
+	"snack-store-api/internal/constants"
+	"snack-store-api/internal/messages"
+	"snack-store-api/internal/model"
+	"snack-store-api/internal/utils"
+
+	"github.com/sirupsen/logrus"
+)
+
+type CacheUseCase struct {
+	Log            *logrus.Logger
+	Cache          *cache.InstrumentedCache
+	ProductUseCase *ProductUseCase
+	ReportUseCase  *ReportUseCase
+}
+
+func NewCacheUseCase(
+	logger *logrus.Logger,
+	cacheStore *cache.InstrumentedCache,
+	productUseCase *ProductUseCase,
+	reportUseCase *ReportUseCase,
+) *CacheUseCase {
+	return &CacheUseCase{
+		Log:            logger,
+		Cache:          cacheStore,
+		ProductUseCase: productUseCase,
+		ReportUseCase:  reportUseCase,
+	}
+}
+
+func (c *CacheUseCase) Stats(_ context.Context) *model.CacheStatsResponse {
+	stats := c.Cache.Stats()
+
+	response := &model.CacheStatsResponse{
+		Namespaces: make([]*model.CacheNamespaceStats, 0, len(stats)),
+	}
+	for _, namespace := range stats {
+		item := &model.CacheNamespaceStats{
+			Namespace:    namespace.Namespace,
+			Hits:         namespace.Hits,
+			Misses:       namespace.Misses,
+			HitRate:      utils.Percentage(namespace.Hits, namespace.Hits+namespace.Misses),
+			Errors:       namespace.Errors,
+			Operations:   namespace.Operations,
+			MaxLatencyMs: durationMs(namespace.MaxLatency),
+		}
+		if namespace.Operations > 0 {
+			item.AvgLatencyMs = durationMs(namespace.TotalLatency / time.Duration(namespace.Operations))
+		}
+		response.Namespaces = append(response.Namespaces, item)
+	}
+
+	return response
+}
+
+func (c *CacheUseCase) Inspect(ctx context.Context, request *model.CacheKeyRequest) (*model.CacheKeyResponse, error) {
+	ttl, exists, err := c.Cache.TTL(ctx, request.Key)
+	if err != nil {
+		c.Log.Warnf("Failed to get cache ttl : %+v", err)
+		return nil, utils.Error(messages.InternalServerError, http.StatusInternalServerError, err)
+	}
+
+	response := &model.CacheKeyResponse{
+		Key:       request.Key,
+		Namespace: cache.Namespace(request.Key),
+		Exists:    exists,
+	}
+	if exists && ttl > 0 {
+		ttlMs := ttl.Milliseconds()
+		response.TTLMs = &ttlMs
+	}
+
+	return response, nil
+}
+
+func (c *CacheUseCase) Flush(ctx context.Context, request *model.FlushCacheRequest) (*model.FlushCacheResponse, error) {
+	prefix, ok := constants.CacheFlushNamespaces[request.Namespace]
+	if !ok {
+		return nil, utils.Error(messages.InvalidRequestData, http.StatusBadRequest, nil)
+	}
+
+	if err := c.Cache.DelByPrefix(ctx, prefix); err != nil {
+		c.Log.Warnf("Failed to flush cache namespace %s : %+v", request.Namespace, err)
+		return nil, utils.Error(messages.InternalServerError, http.StatusInternalServerError, err)
+	}
+
+	c.Log.Infof("Cache namespace %s flushed", request.Namespace)
+	return &model.FlushCacheResponse{Namespace: request.Namespace, Prefix: prefix}, nil
+}
+
+// Warm loads the product list and the daily transactions report of every day
+// in the range, plus the report of the whole range, through the same cached
+// paths the public endpoints use.
+func (c *CacheUseCase) Warm(ctx context.Context, request *model.WarmCacheRequest) (*model.WarmCacheResponse, error) {
+	startDate, endDate, err := parseDateRange(c.Log, request.Start, request.End)
+	if err != nil {
+		return nil, err
+	}
+	if endDate.Sub(startDate) > constants.MaxCacheWarmDays*24*time.Hour {
+		return nil, utils.Error(messages.ErrCacheWarmRange, http.StatusBadRequest, nil)
+	}
+
+	response := &model.WarmCacheResponse{Start: request.Start, End: request.End}
+	for day := startDate; day.Before(endDate); day = day.AddDate(0, 0, 1) {
+		date := day.Format(constants.DateLayout)
+
+		if _, err := c.ProductUseCase.ListByDate(ctx, &model.GetProductRequest{Date: date}); err != nil {
+			return nil, err
+		}
+		response.ProductDates++
+
+		if _, err := c.ReportUseCase.Transactions(ctx, &model.ReportTransactionsRequest{Start: date, End: date}); err != nil {
+			return nil, err
+		}
+		response.Reports++
+	}
+
+	if request.Start != request.End {
+		if _, err := c.ReportUseCase.Transactions(ctx, &model.ReportTransactionsRequest{Start: request.Start, End: request.End}); err != nil {
+			return nil, err
+		}
+		response.Reports++
+	}
+
+	return response, nil
+}
+
+func durationMs(duration time.Duration) float64 {
+	return float64(duration.Microseconds()) / 1000
+}
@@ -0,0 +1,28 @@
+package usecase
+
+import (
+	"net/http"
+	"strings"
+
+	"snack-store-api/internal/messages"
+	"snack-store-api/internal/repository"
+	"snack-store-api/internal/utils"
+
+	"github.com/sirupsen/logrus"
+)
+
+// decodeKeyset turns an opaque cursor into a keyset position. An empty cursor
+// means the first page and yields nil.
+func decodeKeyset(log *logrus.Logger, cursor string) (*repository.Keyset, error) {
+	if strings.TrimSpace(cursor) == "" {
+		return nil, nil
+	}
+
+	at, id, err := utils.DecodeCursor(cursor)
+	if err != nil {
+		log.Warnf("Invalid cursor : %+v", err)
+		return nil, utils.Error(messages.ErrInvalidCursor, http.StatusBadRequest, err)
+	}
+
+	return &repository.Keyset{At: at, ID: id}, nil
+}
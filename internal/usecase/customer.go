@@ -3,7 +3,9 @@ package usecase
 import (
 	"context"
 	"net/http"
+	"time"
 
+	"snack-store-api/internal/entity"
 	"snack-store-api/internal/messages"
 	"snack-store-api/internal/model"
 	"snack-store-api/internal/model/converter"
@@ -36,6 +38,10 @@ func (c *CustomerUseCase) List(
 	ctx context.Context,
 	request *model.GetCustomerRequest,
 ) ([]*model.CustomerResponse, model.PageMetadata, error) {
+	if request.Segment != "" {
+		return c.listBySegment(ctx, request)
+	}
+
 	db := c.DB.WithContext(ctx)
 
 	totalItem, err := c.CustomerRepository.CountAll(db)
@@ -59,3 +65,114 @@ func (c *CustomerUseCase) List(
 	paging := utils.BuildPageMetadata(request.Page, request.PageSize, totalItem)
 	return responses, paging, nil
 }
+
+func (c *CustomerUseCase) ListByCursor(
+	ctx context.Context,
+	request *model.GetCustomerRequest,
+) ([]*model.CustomerResponse, model.CursorMetadata, error) {
+	// Segments are ordered by monetary value, which the created_at keyset
+	// cannot follow.
+	if request.Segment != "" {
+		return nil, model.CursorMetadata{}, utils.Error(messages.ErrSegmentCursor, http.StatusBadRequest, nil)
+	}
+
+	after, err := decodeKeyset(c.Log, request.Cursor)
+	if err != nil {
+		return nil, model.CursorMetadata{}, err
+	}
+
+	db := c.DB.WithContext(ctx)
+
+	var totalItem *int64
+	if request.WithCount {
+		count, err := c.CustomerRepository.CountAll(db)
+		if err != nil {
+			c.Log.Warnf("Failed to count customers : %+v", err)
+			return nil, model.CursorMetadata{}, utils.Error(messages.InternalServerError, http.StatusInternalServerError, err)
+		}
+		totalItem = &count
+	}
+
+	customers, err := c.CustomerRepository.FindAfter(db, after, request.PageSize+1)
+	if err != nil {
+		c.Log.Warnf("Failed to query customers : %+v", err)
+		return nil, model.CursorMetadata{}, utils.Error(messages.InternalServerError, http.StatusInternalServerError, err)
+	}
+
+	nextCursor := ""
+	if len(customers) > request.PageSize {
+		customers = customers[:request.PageSize]
+		last := customers[len(customers)-1]
+		nextCursor = utils.EncodeCursor(last.CreatedAt, last.ID)
+	}
+
+	responses := make([]*model.CustomerResponse, 0, len(customers))
+	for i := range customers {
+		responses = append(responses, converter.CustomerToResponse(&customers[i]))
+	}
+
+	return responses, utils.BuildCursorMetadata(request.PageSize, nextCursor, totalItem), nil
+}
+
+// listBySegment pages through the customers of one RFM segment. Scores are
+// relative to every customer with a purchase, so the whole population is
+// scored before filtering.
+func (c *CustomerUseCase) listBySegment(
+	ctx context.Context,
+	request *model.GetCustomerRequest,
+) ([]*model.CustomerResponse, model.PageMetadata, error) {
+	rows, scores, err := c.Scored(ctx, request.Segment)
+	if err != nil {
+		return nil, model.PageMetadata{}, err
+	}
+
+	offset := min((request.Page-1)*request.PageSize, len(rows))
+	end := min(offset+request.PageSize, len(rows))
+
+	responses := make([]*model.CustomerResponse, 0, end-offset)
+	for i := offset; i < end; i++ {
+		responses = append(responses, converter.CustomerRFMToResponse(&rows[i], scores[i]))
+	}
+
+	paging := utils.BuildPageMetadata(request.Page, request.PageSize, int64(len(rows)))
+	return responses, paging, nil
+}
+
+// Scored returns every customer with a purchase in the given segment (or all
+// segments when empty) ordered by monetary value, with their RFM scores at the
+// same index.
+func (c *CustomerUseCase) Scored(
+	ctx context.Context,
+	segment string,
+) ([]repository.CustomerRFMRow, []entity.RFMScore, error) {
+	rows, err := c.CustomerRepository.FindRFMStats(c.DB.WithContext(ctx))
+	if err != nil {
+		c.Log.Warnf("Failed to query customer segments : %+v", err)
+		return nil, nil, utils.Error(messages.InternalServerError, http.StatusInternalServerError, err)
+	}
+
+	stats := make([]entity.RFMStats, 0, len(rows))
+	for _, row := range rows {
+		stats = append(stats, entity.RFMStats{
+			FirstPurchaseAt: row.FirstPurchaseAt,
+			LastPurchaseAt:  row.LastPurchaseAt,
+			Frequency:       row.Frequency,
+			Monetary:        row.Monetary,
+		})
+	}
+	scores := entity.ScoreRFM(stats, time.Now())
+	if segment == "" {
+		return rows, scores, nil
+	}
+
+	filteredRows := make([]repository.CustomerRFMRow, 0)
+	filteredScores := make([]entity.RFMScore, 0)
+	for i, score := range scores {
+		if score.Segment == segment {
+			filteredRows = append(filteredRows, rows[i])
+			filteredScores = append(filteredScores, score)
+		}
+	}
+
+	return filteredRows, filteredScores, nil
+}
@@ -0,0 +1,44 @@
+package usecase
+
+import (
+	"net/http"
+	"time"
+
+	"snack-store-api/internal/constants"
+	"snack-store-api/internal/messages"
+	"snack-store-api/internal/utils"
+
+	"github.com/sirupsen/logrus"
+)
+
+// parseDateRange parses inclusive start/end dates and returns an exclusive end bound.
+func parseDateRange(log *logrus.Logger, start, end string) (time.Time, time.Time, error) {
+	startDate, err := time.Parse(constants.DateLayout, start)
+	if err != nil {
+		log.Warnf("Invalid start date : %+v", err)
+		return time.Time{}, time.Time{}, utils.Error(messages.FailedInputFormat, http.StatusBadRequest, err)
+	}
+
+	endDate, err := time.Parse(constants.DateLayout, end)
+	if err != nil {
+		log.Warnf("Invalid end date : %+v", err)
+		return time.Time{}, time.Time{}, utils.Error(messages.FailedInputFormat, http.StatusBadRequest, err)
+	}
+
+	if endDate.Before(startDate) {
+		return time.Time{}, time.Time{}, utils.Error(messages.InvalidRequestData, http.StatusBadRequest, nil)
+	}
+
+	return startDate, endDate.AddDate(0, 0, 1), nil
+}
+
+// parseStoreDateRange is parseDateRange with both bounds at midnight of the
+// store location, for reports that follow the store's calendar.
+func parseStoreDateRange(log *logrus.Logger, start, end string, location *time.Location) (time.Time, time.Time, error) {
+	startDate, endDate, err := parseDateRange(log, start, end)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	return inLocation(startDate, location), inLocation(endDate, location), nil
+}
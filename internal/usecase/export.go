@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"io"
+	"net/http"
+	"time"
+
+	"snack-store-api/internal/export"
+	"snack-store-api/internal/messages"
+	"snack-store-api/internal/repository"
+	"snack-store-api/internal/utils"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+var transactionExportColumns = []export.Column{
+	{Title: "Transaction ID"},
+	{Title: "Receipt No"},
+	{Title: "Transaction At", Kind: export.KindDateTime},
+	{Title: "Customer"},
+	{Title: "Product"},
+	{Title: "Size"},
+	{Title: "Flavor"},
+	{Title: "Qty", Kind: export.KindInt},
+	{Title: "Unit Price", Kind: export.KindInt},
+	{Title: "Net Amount", Kind: export.KindInt},
+	{Title: "Tax Amount", Kind: export.KindInt},
+	{Title: "Total Price", Kind: export.KindInt},
+	{Title: "Points Earned", Kind: export.KindInt},
+}
+
+func newExportWriter(
+	log *logrus.Logger,
+	format string,
+	locale string,
+	out io.Writer,
+	location *time.Location,
+) (export.Writer, error) {
+	writer, err := export.NewWriter(format, out, locale, location)
+	if err != nil {
+		log.Warnf("Failed to create export writer : %+v", err)
+		return nil, utils.Error(messages.ErrExport, http.StatusInternalServerError, err)
+	}
+
+	return writer, nil
+}
+
+// writeTransactionSheet streams the filtered transactions into a new sheet.
+func writeTransactionSheet(
+	db *gorm.DB,
+	transactionRepository *repository.TransactionRepository,
+	filter *repository.TransactionFilter,
+	writer export.Writer,
+) error {
+	if err := writer.Sheet("Transactions", transactionExportColumns); err != nil {
+		return err
+	}
+
+	return transactionRepository.StreamByFilter(db, filter, func(row *repository.TransactionExportRow) error {
+		return writer.Row(
+			row.ID.String(),
+			row.ReceiptNo,
+			row.TransactionAt,
+			row.CustomerName,
+			row.ProductName,
+			row.Size,
+			row.Flavor,
+			row.Qty,
+			row.UnitPrice,
+			row.NetAmount,
+			row.TaxAmount,
+			row.TotalPrice,
+			row.PointsEarned,
+		)
+	})
+}
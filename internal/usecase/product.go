@@ -24,6 +24,7 @@ type ProductUseCase struct {
 	Log               *logrus.Logger
 	ProductRepository *repository.ProductRepository
 	Cache             cache.Cache
+	Loader            *cache.Loader
 }
 
 func NewProductUseCase(
@@ -31,12 +32,14 @@ func NewProductUseCase(
 	logger *logrus.Logger,
 	productRepository *repository.ProductRepository,
 	cacheStore cache.Cache,
+	cacheLoader *cache.Loader,
 ) *ProductUseCase {
 	return &ProductUseCase{
 		DB:                db,
 		Log:               logger,
 		ProductRepository: productRepository,
 		Cache:             cacheStore,
+		Loader:            cacheLoader,
 	}
 }
 
@@ -51,39 +54,33 @@ func (c *ProductUseCase) ListByDate(
 	}
 
 	cacheKey := productCacheKey(request.Date)
-	if c.Cache != nil {
-		cached, ok, err := c.Cache.Get(ctx, cacheKey)
+	payload, err := c.Loader.Load(ctx, cacheKey, constants.ProductCacheTTL, func(ctx context.Context) (string, error) {
+		products, err := c.ProductRepository.FindByManufacturedDate(c.DB.WithContext(ctx), manufacturedDate)
 		if err != nil {
-			c.Log.Warnf("Failed to get product cache : %+v", err)
+			c.Log.Warnf("Failed to query products : %+v", err)
+			return "", utils.Error(messages.InternalServerError, http.StatusInternalServerError, err)
 		}
 
-		if ok {
-			var cachedResponses []*model.ProductResponse
-			if err := json.Unmarshal([]byte(cached), &cachedResponses); err == nil {
-				return cachedResponses, nil
-			}
-			c.Log.Warnf("Failed to decode product cache")
+		responses := make([]*model.ProductResponse, 0, len(products))
+		for i := range products {
+			responses = append(responses, converter.ProductToResponse(&products[i]))
 		}
-	}
 
-	products, err := c.ProductRepository.FindByManufacturedDate(c.DB.WithContext(ctx), manufacturedDate)
-	if err != nil {
-		c.Log.Warnf("Failed to query products : %+v", err)
-		return nil, utils.Error(messages.InternalServerError, http.StatusInternalServerError, err)
-	}
-
-	responses := make([]*model.ProductResponse, 0, len(products))
-	for i := range products {
-		responses = append(responses, converter.ProductToResponse(&products[i]))
-	}
-
-	if c.Cache != nil {
 		payload, err := json.Marshal(responses)
 		if err != nil {
-			c.Log.Warnf("Failed to encode product cache : %+v", err)
-		} else if err := c.Cache.Set(ctx, cacheKey, string(payload), constants.ProductCacheTTL); err != nil {
-			c.Log.Warnf("Failed to set product cache : %+v", err)
+			c.Log.Warnf("Failed to encode products : %+v", err)
+			return "", utils.Error(messages.InternalServerError, http.StatusInternalServerError, err)
 		}
+		return string(payload), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var responses []*model.ProductResponse
+	if err := json.Unmarshal([]byte(payload), &responses); err != nil {
+		c.Log.Warnf("Failed to decode products : %+v", err)
+		return nil, utils.Error(messages.InternalServerError, http.StatusInternalServerError, err)
 	}
 
 	return responses, nil
@@ -105,6 +102,7 @@ func (c *ProductUseCase) Create(
 		Flavor:           request.Flavor,
 		Size:             request.Size,
 		Price:            request.Price,
+		CostPrice:        request.CostPrice,
 		StockQty:         request.StockQty,
 		ManufacturedDate: manufacturedDate,
 	}
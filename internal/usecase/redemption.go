@@ -28,7 +28,9 @@ type RedemptionUseCase struct {
 	CustomerRepository   *repository.CustomerRepository
 	ProductRepository    *repository.ProductRepository
 	RedemptionRepository *repository.RedemptionRepository
+	ShiftRepository      *repository.ShiftRepository
 	Cache                cache.Cache
+	ShiftRequired        bool
 }
 
 func NewRedemptionUseCase(
@@ -37,7 +39,9 @@ func NewRedemptionUseCase(
 	customerRepository *repository.CustomerRepository,
 	productRepository *repository.ProductRepository,
 	redemptionRepository *repository.RedemptionRepository,
+	shiftRepository *repository.ShiftRepository,
 	cacheStore cache.Cache,
+	shiftRequired bool,
 ) *RedemptionUseCase {
 	return &RedemptionUseCase{
 		DB:                   db,
@@ -45,7 +49,9 @@ func NewRedemptionUseCase(
 		CustomerRepository:   customerRepository,
 		ProductRepository:    productRepository,
 		RedemptionRepository: redemptionRepository,
+		ShiftRepository:      shiftRepository,
 		Cache:                cacheStore,
+		ShiftRequired:        shiftRequired,
 	}
 }
 
@@ -73,6 +79,11 @@ func (c *RedemptionUseCase) Create(
 	tx := c.DB.WithContext(ctx).Begin()
 	defer tx.Rollback()
 
+	shiftID, err := activeShiftID(tx, c.Log, c.ShiftRepository, request.CashierName, c.ShiftRequired)
+	if err != nil {
+		return nil, err
+	}
+
 	var customer entity.Customer
 	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
 		Where("lower(name) = ?", strings.ToLower(customerName)).
@@ -125,6 +136,7 @@ func (c *RedemptionUseCase) Create(
 	redemption := entity.Redemption{
 		CustomerID:  customer.ID,
 		ProductID:   product.ID,
+		ShiftID:     shiftID,
 		Qty:         request.Qty,
 		PointsSpent: totalPoints,
 		RedeemAt:    redeemAt,
@@ -148,6 +160,137 @@ func (c *RedemptionUseCase) Create(
 	return converter.RedemptionToResponse(&redemption), nil
 }
 
+func (c *RedemptionUseCase) List(
+	ctx context.Context,
+	request *model.GetRedemptionRequest,
+) ([]*model.RedemptionResponse, model.PageMetadata, error) {
+	filter, err := c.buildFilter(request)
+	if err != nil {
+		return nil, model.PageMetadata{}, err
+	}
+
+	db := c.DB.WithContext(ctx)
+
+	totalItem, err := c.RedemptionRepository.CountByFilter(db, filter)
+	if err != nil {
+		c.Log.Warnf("Failed to count redemptions : %+v", err)
+		return nil, model.PageMetadata{}, utils.Error(messages.InternalServerError, http.StatusInternalServerError, err)
+	}
+
+	offset := (request.Page - 1) * request.PageSize
+	redemptions, err := c.RedemptionRepository.FindByFilter(db, filter, request.PageSize, offset)
+	if err != nil {
+		c.Log.Warnf("Failed to query redemptions : %+v", err)
+		return nil, model.PageMetadata{}, utils.Error(messages.InternalServerError, http.StatusInternalServerError, err)
+	}
+
+	responses := make([]*model.RedemptionResponse, 0, len(redemptions))
+	for i := range redemptions {
+		responses = append(responses, converter.RedemptionToResponse(&redemptions[i]))
+	}
+
+	paging := utils.BuildPageMetadata(request.Page, request.PageSize, totalItem)
+	return responses, paging, nil
+}
+
+func (c *RedemptionUseCase) ListByCursor(
+	ctx context.Context,
+	request *model.GetRedemptionRequest,
+) ([]*model.RedemptionResponse, model.CursorMetadata, error) {
+	filter, err := c.buildFilter(request)
+	if err != nil {
+		return nil, model.CursorMetadata{}, err
+	}
+
+	after, err := decodeKeyset(c.Log, request.Cursor)
+	if err != nil {
+		return nil, model.CursorMetadata{}, err
+	}
+
+	db := c.DB.WithContext(ctx)
+
+	var totalItem *int64
+	if request.WithCount {
+		count, err := c.RedemptionRepository.CountByFilter(db, filter)
+		if err != nil {
+			c.Log.Warnf("Failed to count redemptions : %+v", err)
+			return nil, model.CursorMetadata{}, utils.Error(messages.InternalServerError, http.StatusInternalServerError, err)
+		}
+		totalItem = &count
+	}
+
+	filter.After = after
+	redemptions, err := c.RedemptionRepository.FindByFilter(db, filter, request.PageSize+1, 0)
+	if err != nil {
+		c.Log.Warnf("Failed to query redemptions : %+v", err)
+		return nil, model.CursorMetadata{}, utils.Error(messages.InternalServerError, http.StatusInternalServerError, err)
+	}
+
+	nextCursor := ""
+	if len(redemptions) > request.PageSize {
+		redemptions = redemptions[:request.PageSize]
+		last := redemptions[len(redemptions)-1]
+		nextCursor = utils.EncodeCursor(last.RedeemAt, last.ID)
+	}
+
+	responses := make([]*model.RedemptionResponse, 0, len(redemptions))
+	for i := range redemptions {
+		responses = append(responses, converter.RedemptionToResponse(&redemptions[i]))
+	}
+
+	return responses, utils.BuildCursorMetadata(request.PageSize, nextCursor, totalItem), nil
+}
+
+func (c *RedemptionUseCase) buildFilter(request *model.GetRedemptionRequest) (*repository.RedemptionFilter, error) {
+	startDate, endDate, err := parseDateRange(c.Log, strings.TrimSpace(request.Start), strings.TrimSpace(request.End))
+	if err != nil {
+		return nil, err
+	}
+
+	filter := &repository.RedemptionFilter{
+		StartDate:    startDate,
+		EndDate:      endDate,
+		CustomerName: strings.TrimSpace(request.CustomerName),
+		Size:         request.Size,
+		SortAsc:      request.Sort == constants.SortAsc,
+	}
+
+	if request.ProductID != "" {
+		productID, err := uuid.Parse(request.ProductID)
+		if err != nil {
+			c.Log.Warnf("Invalid product_id : %+v", err)
+			return nil, utils.Error(messages.ErrInvalidIDFormat, http.StatusBadRequest, err)
+		}
+		filter.ProductID = &productID
+	}
+
+	return filter, nil
+}
+
+func (c *RedemptionUseCase) Detail(
+	ctx context.Context,
+	request *model.GetRedemptionDetailRequest,
+) (*model.RedemptionResponse, error) {
+	redemptionID, err := uuid.Parse(strings.TrimSpace(request.ID))
+	if err != nil {
+		c.Log.Warnf("Invalid redemption id : %+v", err)
+		return nil, utils.Error(messages.ErrInvalidIDFormat, http.StatusBadRequest, err)
+	}
+
+	var redemption entity.Redemption
+	if err := c.RedemptionRepository.FindDetailById(c.DB.WithContext(ctx), &redemption, redemptionID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.Error(messages.StatusNotFound, http.StatusNotFound, err)
+		}
+		c.Log.Warnf("Failed to find redemption : %+v", err)
+		return nil, utils.Error(messages.InternalServerError, http.StatusInternalServerError, err)
+	}
+
+	return converter.RedemptionToResponse(&redemption), nil
+}
+
+// invalidateCaches drops the product list of the redeemed product. The cached
+// transactions report does not read redemptions, so it is left alone.
 func (c *RedemptionUseCase) invalidateCaches(ctx context.Context, product *entity.Product) {
 	if c.Cache == nil || product == nil {
 		return
@@ -157,8 +300,4 @@ func (c *RedemptionUseCase) invalidateCaches(ctx context.Context, product *entit
 	if err := c.Cache.Del(ctx, cacheKey); err != nil {
 		c.Log.Warnf("Failed to invalidate product cache : %+v", err)
 	}
-
-	if err := c.Cache.DelByPrefix(ctx, constants.ReportCacheKeyPrefix); err != nil {
-		c.Log.Warnf("Failed to invalidate report cache : %+v", err)
-	}
 }
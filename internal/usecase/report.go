@@ -3,6 +3,9 @@ package usecase
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"io"
+	"math"
 	"net/http"
 	"strings"
 	"time"
@@ -10,12 +13,15 @@ import (
 	"snack-store-api/internal/cache"
 	"snack-store-api/internal/constants"
 	"snack-store-api/internal/entity"
+	"snack-store-api/internal/export"
 	"snack-store-api/internal/messages"
 	"snack-store-api/internal/model"
+	"snack-store-api/internal/model/converter"
 	"snack-store-api/internal/repository"
 	"snack-store-api/internal/utils"
 
 	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
 	"gorm.io/gorm"
 )
 
@@ -23,20 +29,32 @@ type ReportUseCase struct {
 	DB               *gorm.DB
 	Log              *logrus.Logger
 	ReportRepository *repository.ReportRepository
-	Cache            cache.Cache
+	// TransactionRepository streams transaction rows for report exports.
+	TransactionRepository *repository.TransactionRepository
+	// Loader caches the transactions report and coalesces concurrent misses.
+	Loader   *cache.Loader
+	Location *time.Location
 }
 
 func NewReportUseCase(
 	db *gorm.DB,
 	logger *logrus.Logger,
 	reportRepository *repository.ReportRepository,
-	cacheStore cache.Cache,
+	transactionRepository *repository.TransactionRepository,
+	cacheLoader *cache.Loader,
+	location *time.Location,
 ) *ReportUseCase {
+	if location == nil {
+		location = time.UTC
+	}
+
 	return &ReportUseCase{
-		DB:               db,
-		Log:              logger,
-		ReportRepository: reportRepository,
-		Cache:            cacheStore,
+		DB:                    db,
+		Log:                   logger,
+		ReportRepository:      reportRepository,
+		TransactionRepository: transactionRepository,
+		Loader:                cacheLoader,
+		Location:              location,
 	}
 }
 
@@ -47,114 +65,705 @@ func (c *ReportUseCase) Transactions(
 	startStr := strings.TrimSpace(request.Start)
 	endStr := strings.TrimSpace(request.End)
 
-	startDate, err := time.Parse(constants.DateLayout, startStr)
+	startDate, endDate, err := parseStoreDateRange(c.Log, startStr, endStr, c.Location)
+	if err != nil {
+		return nil, err
+	}
+
+	compareStart, compareEnd := entity.ComparisonPeriod(startDate, endDate, request.Compare)
+	periods := [][2]time.Time{{startDate, endDate}}
+	if request.Compare != entity.CompareNone {
+		periods = append(periods, [2]time.Time{compareStart, compareEnd})
+	}
+
+	version, err := reportCacheVersion(ctx, c.Loader.Cache, periods...)
+	if err != nil {
+		c.Log.Warnf("Failed to get report cache version : %+v", err)
+		return c.comparedTransactionsReport(ctx, startDate, endDate, request.Compare)
+	}
+
+	cacheKey := reportCacheKey(startStr, endStr, request.Compare, version)
+	payload, err := c.Loader.Load(ctx, cacheKey, constants.ReportCacheTTL, func(ctx context.Context) (string, error) {
+		response, err := c.comparedTransactionsReport(ctx, startDate, endDate, request.Compare)
+		if err != nil {
+			return "", err
+		}
+
+		payload, err := json.Marshal(response)
+		if err != nil {
+			c.Log.Warnf("Failed to encode report : %+v", err)
+			return "", utils.Error(messages.InternalServerError, http.StatusInternalServerError, err)
+		}
+		return string(payload), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var response model.ReportTransactionsResponse
+	if err := json.Unmarshal([]byte(payload), &response); err != nil {
+		c.Log.Warnf("Failed to decode report : %+v", err)
+		return nil, utils.Error(messages.InternalServerError, http.StatusInternalServerError, err)
+	}
+
+	return &response, nil
+}
+
+// comparedTransactionsReport computes the report for [startDate, endDate) and,
+// unless compare is none, the comparison period with its deltas.
+func (c *ReportUseCase) comparedTransactionsReport(
+	ctx context.Context,
+	startDate time.Time,
+	endDate time.Time,
+	compare string,
+) (*model.ReportTransactionsResponse, error) {
+	compareStart, compareEnd := entity.ComparisonPeriod(startDate, endDate, compare)
+
+	// The sub-queries of both periods share one group, so the whole request
+	// stays within ReportQueryParallelism; the first failure cancels the rest.
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(constants.ReportQueryParallelism)
+
+	current := c.transactionsReport(group, groupCtx, startDate, endDate)
+	var comparison func() *model.ReportTransactionsResponse
+	if compare != entity.CompareNone {
+		comparison = c.transactionsReport(group, groupCtx, compareStart, compareEnd)
+	}
+	if err := group.Wait(); err != nil {
+		return nil, utils.Error(messages.InternalServerError, http.StatusInternalServerError, err)
+	}
+
+	response := current()
+	if comparison != nil {
+		previous := comparison()
+		response.Comparison = &model.ReportComparison{
+			Mode:   compare,
+			Start:  compareStart.Format(constants.DateLayout),
+			End:    compareEnd.AddDate(0, 0, -1).Format(constants.DateLayout),
+			Report: previous,
+			Deltas: model.ReportTransactionsDeltas{
+				TotalCustomer:     metricDelta(response.TotalCustomer, previous.TotalCustomer),
+				TotalIncome:       metricDelta(int64(response.TotalIncome), int64(previous.TotalIncome)),
+				TotalProductsSold: metricDelta(int64(response.TotalProductsSold), int64(previous.TotalProductsSold)),
+			},
+		}
+	}
+
+	return response, nil
+}
+
+// ExportTransactions writes the transaction report to out. XLSX gets a Summary
+// sheet with the report metrics next to the Transactions sheet; CSV, having a
+// single table, only carries the transactions of the period.
+func (c *ReportUseCase) ExportTransactions(
+	ctx context.Context,
+	request *model.ReportTransactionsRequest,
+	format string,
+	locale string,
+	out io.Writer,
+) error {
+	startDate, endDate, err := parseStoreDateRange(c.Log, strings.TrimSpace(request.Start), strings.TrimSpace(request.End), c.Location)
 	if err != nil {
-		c.Log.Warnf("Invalid start date : %+v", err)
-		return nil, utils.Error(messages.FailedInputFormat, http.StatusBadRequest, err)
+		return err
 	}
 
-	endDate, err := time.Parse(constants.DateLayout, endStr)
+	writer, err := newExportWriter(c.Log, format, locale, out, c.Location)
 	if err != nil {
-		c.Log.Warnf("Invalid end date : %+v", err)
-		return nil, utils.Error(messages.FailedInputFormat, http.StatusBadRequest, err)
+		return err
+	}
+	// Releases the workbook's temporary files when the export fails part way.
+	defer writer.Abort()
+
+	if writer.MultiSheet() {
+		report, err := c.Transactions(ctx, request)
+		if err != nil {
+			return err
+		}
+
+		if err := writeReportSummarySheet(writer, request, report); err != nil {
+			c.Log.Warnf("Failed to export report summary : %+v", err)
+			return utils.Error(messages.ErrExport, http.StatusInternalServerError, err)
+		}
+	}
+
+	filter := &repository.TransactionFilter{StartDate: startDate, EndDate: endDate}
+	if err := writeTransactionSheet(c.DB.WithContext(ctx), c.TransactionRepository, filter, writer); err != nil {
+		c.Log.Warnf("Failed to export report transactions : %+v", err)
+		return utils.Error(messages.ErrExport, http.StatusInternalServerError, err)
 	}
 
-	if endDate.Before(startDate) {
-		return nil, utils.Error(messages.InvalidRequestData, http.StatusBadRequest, nil)
+	if err := writer.Close(); err != nil {
+		c.Log.Warnf("Failed to finish report export : %+v", err)
+		return utils.Error(messages.ErrExport, http.StatusInternalServerError, err)
 	}
 
-	cacheKey := reportCacheKey(startStr, endStr)
-	if c.Cache != nil {
-		cached, ok, err := c.Cache.Get(ctx, cacheKey)
+	return nil
+}
+
+// transactionsReport schedules the uncached summary queries for
+// [startDate, endDate) on group and returns a function that assembles the
+// response once group.Wait has succeeded.
+func (c *ReportUseCase) transactionsReport(
+	group *errgroup.Group,
+	groupCtx context.Context,
+	startDate time.Time,
+	endDate time.Time,
+) func() *model.ReportTransactionsResponse {
+	var (
+		summary          *repository.TransactionSummaryRow
+		bestSellers      []repository.BestSellerRow
+		lastTransactions []entity.Transaction
+	)
+
+	group.Go(func() error {
+		row, err := c.ReportRepository.GetTransactionSummary(c.DB.WithContext(groupCtx), startDate, endDate)
 		if err != nil {
-			c.Log.Warnf("Failed to get report cache : %+v", err)
+			c.Log.Warnf("Failed to get transaction summary : %+v", err)
+			return err
 		}
-		if ok {
-			var cachedResponse model.ReportTransactionsResponse
-			if err := json.Unmarshal([]byte(cached), &cachedResponse); err == nil {
-				return &cachedResponse, nil
-			}
-			c.Log.Warnf("Failed to decode report cache")
+		summary = row
+		return nil
+	})
+
+	group.Go(func() error {
+		rows, err := c.ReportRepository.GetBestSellers(c.DB.WithContext(groupCtx), startDate, endDate)
+		if err != nil {
+			c.Log.Warnf("Failed to get best seller : %+v", err)
+			return err
+		}
+		bestSellers = rows
+		return nil
+	})
+
+	group.Go(func() error {
+		rows, err := c.ReportRepository.GetLastTransactions(
+			c.DB.WithContext(groupCtx),
+			startDate,
+			endDate,
+			constants.ReportLastTransactionLimit,
+		)
+		if err != nil {
+			c.Log.Warnf("Failed to get last transactions : %+v", err)
+			return err
 		}
+		lastTransactions = rows
+		return nil
+	})
+
+	return func() *model.ReportTransactionsResponse {
+		return buildTransactionsReport(summary, bestSellers, lastTransactions)
 	}
+}
 
-	endDate = endDate.AddDate(0, 0, 1)
+func buildTransactionsReport(
+	summary *repository.TransactionSummaryRow,
+	bestSellers []repository.BestSellerRow,
+	lastTransactions []entity.Transaction,
+) *model.ReportTransactionsResponse {
+	items := make([]*model.ReportTransactionItem, 0, len(lastTransactions))
+	for i := range lastTransactions {
+		items = append(items, mapReportTransaction(&lastTransactions[i]))
+	}
 
+	response := &model.ReportTransactionsResponse{
+		TotalCustomer:     summary.TotalCustomer,
+		HasNewCustomer:    summary.HasNewCustomer,
+		TotalIncome:       int(summary.TotalIncome),
+		TotalProductsSold: int(summary.TotalProductsSold),
+		LastTransactions:  items,
+	}
+
+	for _, row := range bestSellers {
+		response.BestSellers = append(response.BestSellers, &model.ReportBestSeller{
+			ProductName: row.ProductName,
+			Size:        row.Size,
+			Flavor:      row.Flavor,
+			TotalQty:    row.TotalQty,
+		})
+	}
+	if len(response.BestSellers) > 0 {
+		response.BestSeller = response.BestSellers[0]
+	}
+
+	return response
+}
+
+func (c *ReportUseCase) Tax(
+	ctx context.Context,
+	request *model.ReportTaxRequest,
+) (*model.ReportTaxResponse, error) {
+	startStr := strings.TrimSpace(request.Start)
+	endStr := strings.TrimSpace(request.End)
+
+	// Tax periods are the store's calendar months.
+	startDate, endDate, err := parseStoreDateRange(c.Log, startStr, endStr, c.Location)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := c.ReportRepository.GetTaxSummary(c.DB.WithContext(ctx), startDate, endDate)
+	if err != nil {
+		c.Log.Warnf("Failed to get tax summary : %+v", err)
+		return nil, utils.Error(messages.InternalServerError, http.StatusInternalServerError, err)
+	}
+
+	response := &model.ReportTaxResponse{
+		Start:   startStr,
+		End:     endStr,
+		Periods: make([]*model.ReportTaxPeriod, 0, len(rows)),
+	}
+
+	for _, row := range rows {
+		response.NetAmount += row.NetAmount
+		response.TaxAmount += row.TaxAmount
+		response.GrossAmount += row.GrossAmount
+		response.Periods = append(response.Periods, &model.ReportTaxPeriod{
+			Period:           row.Period.Format(constants.MonthLayout),
+			TaxRate:          float64(row.TaxRateBps) / 100,
+			TotalTransaction: row.TotalTransaction,
+			NetAmount:        row.NetAmount,
+			TaxAmount:        row.TaxAmount,
+			GrossAmount:      row.GrossAmount,
+		})
+	}
+
+	return response, nil
+}
+
+func (c *ReportUseCase) Sales(
+	ctx context.Context,
+	request *model.ReportSalesRequest,
+) (*model.ReportSalesResponse, error) {
+	startStr := strings.TrimSpace(request.Start)
+	endStr := strings.TrimSpace(request.End)
+
+	// Buckets follow the store's calendar, so the range is taken in the store
+	// timezone too.
+	startDate, endDate, err := parseStoreDateRange(c.Log, startStr, endStr, c.Location)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := c.ReportRepository.GetSalesSeries(
+		c.DB.WithContext(ctx),
+		startDate,
+		endDate,
+		request.Granularity,
+		c.Location.String(),
+	)
+	if err != nil {
+		c.Log.Warnf("Failed to get sales series : %+v", err)
+		return nil, utils.Error(messages.InternalServerError, http.StatusInternalServerError, err)
+	}
+
+	// Distinct customers cannot be summed across buckets, so count them over the whole range.
 	totalCustomer, err := c.ReportRepository.GetTotalCustomer(c.DB.WithContext(ctx), startDate, endDate)
 	if err != nil {
 		c.Log.Warnf("Failed to get total customer : %+v", err)
 		return nil, utils.Error(messages.InternalServerError, http.StatusInternalServerError, err)
 	}
 
-	hasNewCustomer, err := c.ReportRepository.HasNewCustomer(c.DB.WithContext(ctx), startDate, endDate)
+	periods := entity.SeriesBuckets(startDate, endDate, request.Granularity)
+	response := &model.ReportSalesResponse{
+		Start:         startStr,
+		End:           endStr,
+		Granularity:   request.Granularity,
+		TotalCustomer: totalCustomer,
+		Buckets:       make([]*model.ReportSalesBucket, 0, len(periods)),
+	}
+
+	// The query only returns buckets with sales; the rest are zero-filled
+	// from the local calendar.
+	byPeriod := make(map[string]*model.ReportSalesBucket, len(rows))
+	for _, row := range rows {
+		byPeriod[formatPeriod(row.Period, request.Granularity)] = &model.ReportSalesBucket{
+			Income:           row.Income,
+			QtySold:          row.QtySold,
+			TotalTransaction: row.TotalTransaction,
+			TotalCustomer:    row.TotalCustomer,
+		}
+	}
+
+	for _, start := range periods {
+		period := formatPeriod(start, request.Granularity)
+		bucket, ok := byPeriod[period]
+		if !ok {
+			bucket = &model.ReportSalesBucket{}
+		}
+		bucket.Period = period
+
+		response.TotalIncome += bucket.Income
+		response.TotalQtySold += bucket.QtySold
+		response.TotalTransaction += bucket.TotalTransaction
+		response.Buckets = append(response.Buckets, bucket)
+	}
+
+	return response, nil
+}
+
+func (c *ReportUseCase) Breakdown(
+	ctx context.Context,
+	request *model.ReportBreakdownRequest,
+) (*model.ReportBreakdownResponse, error) {
+	startStr := strings.TrimSpace(request.Start)
+	endStr := strings.TrimSpace(request.End)
+
+	startDate, endDate, err := parseStoreDateRange(c.Log, startStr, endStr, c.Location)
 	if err != nil {
-		c.Log.Warnf("Failed to check new customer : %+v", err)
+		return nil, err
+	}
+
+	rows, err := c.ReportRepository.GetSalesBreakdown(
+		c.DB.WithContext(ctx),
+		startDate,
+		endDate,
+		request.GroupBy,
+		request.SortBy,
+		request.Limit,
+	)
+	if err != nil {
+		c.Log.Warnf("Failed to get sales breakdown : %+v", err)
 		return nil, utils.Error(messages.InternalServerError, http.StatusInternalServerError, err)
 	}
 
-	totalIncome, err := c.ReportRepository.GetTotalIncome(c.DB.WithContext(ctx), startDate, endDate)
+	response := &model.ReportBreakdownResponse{
+		Start:   startStr,
+		End:     endStr,
+		GroupBy: request.GroupBy,
+		SortBy:  request.SortBy,
+		Items:   make([]*model.ReportBreakdownItem, 0, len(rows)),
+	}
+
+	for _, row := range rows {
+		response.TotalQtySold = row.AllQty
+		response.TotalRevenue = row.AllRevenue
+		response.Items = append(response.Items, &model.ReportBreakdownItem{
+			Rank:             row.Rank,
+			Key:              row.GroupKey,
+			ProductName:      row.ProductName,
+			Size:             row.Size,
+			Flavor:           row.Flavor,
+			Type:             row.Type,
+			QtySold:          row.TotalQty,
+			Revenue:          row.Revenue,
+			TotalTransaction: row.TotalTransaction,
+			QtyShare:         utils.Percentage(row.TotalQty, row.AllQty),
+			RevenueShare:     utils.Percentage(row.Revenue, row.AllRevenue),
+		})
+	}
+
+	return response, nil
+}
+
+func (c *ReportUseCase) Cohorts(
+	ctx context.Context,
+	request *model.ReportCohortRequest,
+) (*model.ReportCohortResponse, error) {
+	startStr := strings.TrimSpace(request.Start)
+	endStr := strings.TrimSpace(request.End)
+
+	// Signup months and visit days follow the store's calendar.
+	startDate, endDate, err := parseStoreDateRange(c.Log, startStr, endStr, c.Location)
+	if err != nil {
+		return nil, err
+	}
+
+	activity, err := c.ReportRepository.GetCohortActivity(c.DB.WithContext(ctx), startDate, endDate, request.Months)
 	if err != nil {
-		c.Log.Warnf("Failed to get total income : %+v", err)
+		c.Log.Warnf("Failed to get cohort activity : %+v", err)
 		return nil, utils.Error(messages.InternalServerError, http.StatusInternalServerError, err)
 	}
 
-	totalProductsSold, err := c.ReportRepository.GetTotalProductsSold(c.DB.WithContext(ctx), startDate, endDate)
+	summary, err := c.ReportRepository.GetCohortSummary(
+		c.DB.WithContext(ctx),
+		startDate,
+		endDate,
+		endDate.AddDate(0, 0, -request.ChurnDays),
+	)
 	if err != nil {
-		c.Log.Warnf("Failed to get total products sold : %+v", err)
+		c.Log.Warnf("Failed to get cohort summary : %+v", err)
 		return nil, utils.Error(messages.InternalServerError, http.StatusInternalServerError, err)
 	}
 
-	bestSeller, err := c.ReportRepository.GetBestSeller(c.DB.WithContext(ctx), startDate, endDate)
+	return &model.ReportCohortResponse{
+		Start:                startStr,
+		End:                  endStr,
+		Months:               request.Months,
+		ChurnDays:            request.ChurnDays,
+		TotalCustomer:        summary.TotalCustomer,
+		RepeatCustomer:       summary.RepeatCustomer,
+		RepeatPurchaseRate:   utils.Percentage(summary.RepeatCustomer, summary.TotalCustomer),
+		AvgDaysBetweenVisits: math.Round(summary.AvgDaysBetweenVisits*100) / 100,
+		ChurnedCustomer:      summary.ChurnedCustomer,
+		ChurnRate:            utils.Percentage(summary.ChurnedCustomer, summary.TotalCustomer),
+		Cohorts:              converter.CohortActivityToResponse(activity, request.Months, time.Now().In(c.Location)),
+	}, nil
+}
+
+func (c *ReportUseCase) Loyalty(
+	ctx context.Context,
+	request *model.ReportLoyaltyRequest,
+) (*model.ReportLoyaltyResponse, error) {
+	startStr := strings.TrimSpace(request.Start)
+	endStr := strings.TrimSpace(request.End)
+
+	// Points buckets follow the store's calendar, like the sales report.
+	startDate, endDate, err := parseStoreDateRange(c.Log, startStr, endStr, c.Location)
+	if err != nil {
+		return nil, err
+	}
+
+	outstanding, err := c.ReportRepository.GetOutstandingPoints(c.DB.WithContext(ctx))
 	if err != nil {
-		c.Log.Warnf("Failed to get best seller : %+v", err)
+		c.Log.Warnf("Failed to get outstanding points : %+v", err)
 		return nil, utils.Error(messages.InternalServerError, http.StatusInternalServerError, err)
 	}
 
-	lastTransactions, err := c.ReportRepository.GetLastTransactions(
+	redemptionValue, err := c.ReportRepository.GetRedemptionValue(c.DB.WithContext(ctx))
+	if err != nil {
+		c.Log.Warnf("Failed to get redemption value : %+v", err)
+		return nil, utils.Error(messages.InternalServerError, http.StatusInternalServerError, err)
+	}
+
+	rows, err := c.ReportRepository.GetPointsSeries(c.DB.WithContext(ctx), startDate, endDate, request.Granularity)
+	if err != nil {
+		c.Log.Warnf("Failed to get points series : %+v", err)
+		return nil, utils.Error(messages.InternalServerError, http.StatusInternalServerError, err)
+	}
+
+	topRedeemed, err := c.ReportRepository.GetTopRedeemedProducts(
 		c.DB.WithContext(ctx),
 		startDate,
 		endDate,
-		constants.ReportLastTransactionLimit,
+		constants.ReportTopRedeemedLimit,
 	)
 	if err != nil {
-		c.Log.Warnf("Failed to get last transactions : %+v", err)
+		c.Log.Warnf("Failed to get top redeemed products : %+v", err)
 		return nil, utils.Error(messages.InternalServerError, http.StatusInternalServerError, err)
 	}
 
-	items := make([]*model.ReportTransactionItem, 0, len(lastTransactions))
-	for i := range lastTransactions {
-		items = append(items, mapReportTransaction(&lastTransactions[i]))
+	response := &model.ReportLoyaltyResponse{
+		Start:               startStr,
+		End:                 endStr,
+		Granularity:         request.Granularity,
+		OutstandingPoints:   outstanding.TotalPoints,
+		CustomersWithPoints: outstanding.CustomersWithPoints,
+		TopRedeemedProducts: make([]*model.ReportRedeemedProduct, 0, len(topRedeemed)),
+		Buckets:             make([]*model.ReportPointsBucket, 0, len(rows)),
 	}
 
-	response := &model.ReportTransactionsResponse{
-		TotalCustomer:     totalCustomer,
-		HasNewCustomer:    hasNewCustomer,
-		TotalIncome:       totalIncome,
-		TotalProductsSold: totalProductsSold,
-		LastTransactions:  items,
+	// The liability is what honouring the points costs the store, so it is
+	// valued at cost_price; the retail figure is reported alongside. Without
+	// an observed redemption either one is left unknown instead of guessed.
+	if pointValue, liability, ok := entity.PointLiability(
+		outstanding.TotalPoints,
+		redemptionValue.CostedPointsSpent,
+		redemptionValue.ItemCost,
+	); ok {
+		response.PointValue = &pointValue
+		response.EstimatedLiability = &liability
+	}
+	if pointValue, liability, ok := entity.PointLiability(
+		outstanding.TotalPoints,
+		redemptionValue.PointsSpent,
+		redemptionValue.ItemValue,
+	); ok {
+		response.PointRetailValue = &pointValue
+		response.RetailLiability = &liability
+	}
+
+	for _, row := range rows {
+		response.PointsEarned += row.PointsEarned
+		response.PointsRedeemed += row.PointsRedeemed
+		response.Buckets = append(response.Buckets, &model.ReportPointsBucket{
+			Period:         formatPeriod(row.Period, request.Granularity),
+			PointsEarned:   row.PointsEarned,
+			PointsRedeemed: row.PointsRedeemed,
+		})
+	}
+	response.RedemptionRate = utils.Percentage(response.PointsRedeemed, response.PointsEarned)
+
+	for _, row := range topRedeemed {
+		response.TopRedeemedProducts = append(response.TopRedeemedProducts, &model.ReportRedeemedProduct{
+			ProductName:     row.ProductName,
+			Size:            row.Size,
+			Flavor:          row.Flavor,
+			TotalQty:        row.TotalQty,
+			PointsSpent:     row.PointsSpent,
+			TotalRedemption: row.TotalRedemption,
+		})
+	}
+
+	return response, nil
+}
+
+func (c *ReportUseCase) Inventory(
+	ctx context.Context,
+	request *model.ReportInventoryRequest,
+) (*model.ReportInventoryResponse, error) {
+	// Stock ages count calendar days in the store timezone; today is kept at
+	// UTC midnight to line up with the manufactured dates read from the date
+	// column.
+	now := time.Now()
+	local := now.In(c.Location)
+	today := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+
+	rows, err := c.ReportRepository.GetInventory(c.DB.WithContext(ctx), now.AddDate(0, 0, -request.VelocityDays))
+	if err != nil {
+		c.Log.Warnf("Failed to get inventory : %+v", err)
+		return nil, utils.Error(messages.InternalServerError, http.StatusInternalServerError, err)
+	}
+
+	response := &model.ReportInventoryResponse{
+		AsOf:          today.Format(constants.DateLayout),
+		VelocityDays:  request.VelocityDays,
+		DeadStockDays: request.DeadStockDays,
+		Aging:         make([]*model.ReportStockAging, 0, len(entity.StockAgeBuckets)),
+		Items:         make([]*model.ReportInventoryItem, 0, len(rows)),
+	}
+
+	aging := make(map[string]*model.ReportStockAging, len(entity.StockAgeBuckets))
+	for _, bucket := range entity.StockAgeBuckets {
+		aging[bucket] = &model.ReportStockAging{Bucket: bucket}
+		response.Aging = append(response.Aging, aging[bucket])
+	}
+
+	deadSince := now.AddDate(0, 0, -request.DeadStockDays)
+	for _, row := range rows {
+		ageDays := int(today.Sub(row.ManufacturedDate.UTC()).Hours() / 24)
+		item := &model.ReportInventoryItem{
+			ProductID:        row.ProductID.String(),
+			Name:             row.Name,
+			Type:             row.Type,
+			Flavor:           row.Flavor,
+			Size:             row.Size,
+			ManufacturedDate: row.ManufacturedDate.Format(constants.DateLayout),
+			AgeDays:          ageDays,
+			AgeBucket:        entity.StockAgeBucket(ageDays),
+			StockQty:         row.StockQty,
+			Price:            row.Price,
+			CostPrice:        row.CostPrice,
+			StockValue:       row.StockQty * row.Price,
+			RecentQtySold:    row.RecentQty,
+			DailyVelocity:    math.Round(float64(row.RecentQty)/float64(request.VelocityDays)*100) / 100,
+		}
+
+		if row.CostPrice != nil {
+			stockCost := row.StockQty * *row.CostPrice
+			item.StockCost = &stockCost
+			response.TotalStockCost += stockCost
+		} else {
+			response.UnknownCostItems++
+		}
+
+		if row.RecentQty > 0 {
+			daysOfCover := math.Round(float64(row.StockQty)/(float64(row.RecentQty)/float64(request.VelocityDays))*10) / 10
+			item.DaysOfCover = &daysOfCover
+		}
+
+		if row.LastSoldAt != nil {
+			lastSoldAt := row.LastSoldAt.Format(constants.DateTimeLayout)
+			item.LastSoldAt = &lastSoldAt
+		}
+
+		// Stock younger than the dead stock window has not had the chance to sell yet.
+		item.IsDeadStock = ageDays >= request.DeadStockDays &&
+			(row.LastSoldAt == nil || row.LastSoldAt.Before(deadSince))
+		if item.IsDeadStock {
+			response.DeadStockItems++
+			response.DeadStockValue += item.StockValue
+		}
+
+		response.TotalStockQty += item.StockQty
+		response.TotalStockValue += item.StockValue
+		aging[item.AgeBucket].StockQty += item.StockQty
+		aging[item.AgeBucket].StockValue += item.StockValue
+		response.Items = append(response.Items, item)
+	}
+
+	return response, nil
+}
+
+func (c *ReportUseCase) Heatmap(
+	ctx context.Context,
+	request *model.ReportHeatmapRequest,
+) (*model.ReportHeatmapResponse, error) {
+	startStr := strings.TrimSpace(request.Start)
+	endStr := strings.TrimSpace(request.End)
+
+	// The range is read as calendar days in the store's timezone, like the hours.
+	startDate, endDate, err := parseStoreDateRange(c.Log, startStr, endStr, c.Location)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := c.ReportRepository.GetHourlyHeatmap(c.DB.WithContext(ctx), startDate, endDate, c.Location.String())
+	if err != nil {
+		c.Log.Warnf("Failed to get sales heatmap : %+v", err)
+		return nil, utils.Error(messages.InternalServerError, http.StatusInternalServerError, err)
+	}
+
+	response := &model.ReportHeatmapResponse{
+		Start:    startStr,
+		End:      endStr,
+		Timezone: c.Location.String(),
+		Days:     make([]*model.ReportHeatmapDay, 0, 7),
 	}
 
-	if bestSeller != nil {
-		response.BestSeller = &model.ReportBestSeller{
-			ProductName: bestSeller.ProductName,
-			Size:        bestSeller.Size,
-			Flavor:      bestSeller.Flavor,
-			TotalQty:    bestSeller.TotalQty,
+	// Rows use ISO weekdays, 1 (Monday) to 7 (Sunday).
+	for isoWeekday := 1; isoWeekday <= 7; isoWeekday++ {
+		day := &model.ReportHeatmapDay{
+			Weekday: time.Weekday(isoWeekday % 7).String(),
+			Hours:   make([]*model.ReportHeatmapCell, 24),
+		}
+		for hour := range day.Hours {
+			day.Hours[hour] = &model.ReportHeatmapCell{Hour: hour}
 		}
+		response.Days = append(response.Days, day)
 	}
 
-	if c.Cache != nil {
-		payload, err := json.Marshal(response)
-		if err != nil {
-			c.Log.Warnf("Failed to encode report cache : %+v", err)
-		} else if err := c.Cache.Set(ctx, cacheKey, string(payload), constants.ReportCacheTTL); err != nil {
-			c.Log.Warnf("Failed to set report cache : %+v", err)
+	var peak *model.ReportHeatmapPeak
+	for _, row := range rows {
+		if row.IsoWeekday < 1 || row.IsoWeekday > 7 || row.Hour < 0 || row.Hour > 23 {
+			continue
+		}
+
+		day := response.Days[row.IsoWeekday-1]
+		cell := day.Hours[row.Hour]
+		cell.TotalTransaction = row.TotalTransaction
+		cell.Revenue = row.Revenue
+		day.TotalTransaction += row.TotalTransaction
+		day.Revenue += row.Revenue
+		response.TotalTransaction += row.TotalTransaction
+		response.Revenue += row.Revenue
+
+		if peak == nil || row.TotalTransaction > peak.TotalTransaction {
+			peak = &model.ReportHeatmapPeak{
+				Weekday:          day.Weekday,
+				Hour:             row.Hour,
+				TotalTransaction: row.TotalTransaction,
+				Revenue:          row.Revenue,
+			}
 		}
 	}
+	response.Peak = peak
 
 	return response, nil
 }
 
+// inLocation returns midnight of the same calendar date in location.
+func inLocation(date time.Time, location *time.Location) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, location)
+}
+
+func formatPeriod(period time.Time, granularity string) string {
+	if granularity == constants.GranularityMonth {
+		return period.Format(constants.MonthLayout)
+	}
+
+	return period.Format(constants.DateLayout)
+}
+
 func mapReportTransaction(transaction *entity.Transaction) *model.ReportTransactionItem {
 	id := transaction.ID
 	isNewCustomer := transaction.Customer.CreatedAt.Year() == transaction.TransactionAt.Year() &&
@@ -175,6 +784,88 @@ func mapReportTransaction(transaction *entity.Transaction) *model.ReportTransact
 	}
 }
 
-func reportCacheKey(startDate, endDate string) string {
-	return constants.ReportCacheKeyPrefix + startDate + ":" + endDate
+func reportCacheKey(startDate, endDate, compare string, version string) string {
+	key := constants.ReportCacheKeyPrefix + startDate + ":" + endDate
+	if compare != "" {
+		key += ":" + compare
+	}
+
+	return key + ":v" + version
+}
+
+// metricDelta reports the change from previous to current. ChangePercent is nil
+// when there is no previous value to compare against.
+func metricDelta(current, previous int64) model.ReportMetricDelta {
+	delta := model.ReportMetricDelta{
+		Current:  current,
+		Previous: previous,
+		Change:   current - previous,
+	}
+	if previous != 0 {
+		percent := utils.Percentage(current-previous, previous)
+		delta.ChangePercent = &percent
+	}
+
+	return delta
+}
+
+func writeReportSummarySheet(
+	writer export.Writer,
+	request *model.ReportTransactionsRequest,
+	report *model.ReportTransactionsResponse,
+) error {
+	columns := []export.Column{
+		{Title: "Metric"},
+		{Title: "Value", Kind: export.KindInt},
+		{Title: "Note"},
+	}
+	if err := writer.Sheet("Summary", columns); err != nil {
+		return err
+	}
+
+	hasNewCustomer := "no"
+	if report.HasNewCustomer {
+		hasNewCustomer = "yes"
+	}
+
+	rows := [][]interface{}{
+		{"Period", nil, request.Start + " - " + request.End},
+		{"Total customer", report.TotalCustomer, nil},
+		{"Has new customer", nil, hasNewCustomer},
+		{"Total income", report.TotalIncome, nil},
+		{"Total products sold", report.TotalProductsSold, nil},
+	}
+	for _, bestSeller := range report.BestSellers {
+		note := fmt.Sprintf("%s %s %s", bestSeller.ProductName, bestSeller.Size, bestSeller.Flavor)
+		rows = append(rows, []interface{}{"Best seller (qty)", bestSeller.TotalQty, note})
+	}
+
+	if comparison := report.Comparison; comparison != nil {
+		rows = append(rows, []interface{}{"Comparison period", nil, comparison.Mode + ": " + comparison.Start + " - " + comparison.End})
+		for _, metric := range []struct {
+			name  string
+			delta model.ReportMetricDelta
+		}{
+			{name: "Total customer", delta: comparison.Deltas.TotalCustomer},
+			{name: "Total income", delta: comparison.Deltas.TotalIncome},
+			{name: "Total products sold", delta: comparison.Deltas.TotalProductsSold},
+		} {
+			change := "n/a"
+			if metric.delta.ChangePercent != nil {
+				change = fmt.Sprintf("%.2f%%", *metric.delta.ChangePercent)
+			}
+			rows = append(rows,
+				[]interface{}{metric.name + " (previous)", metric.delta.Previous, nil},
+				[]interface{}{metric.name + " (change)", metric.delta.Change, change},
+			)
+		}
+	}
+
+	for _, row := range rows {
+		if err := writer.Row(row...); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
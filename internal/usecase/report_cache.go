@@ -0,0 +1,38 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"snack-store-api/internal/cache"
+	"snack-store-api/internal/constants"
+	"snack-store-api/internal/entity"
+)
+
+// reportCacheVersion combines the version stamps of every bucket the report
+// reads. Retiring any of those buckets changes the version and therefore the
+// key, while sales outside them leave it untouched.
+func reportCacheVersion(ctx context.Context, store cache.Cache, ranges ...[2]time.Time) (string, error) {
+	if store == nil {
+		return "", nil
+	}
+
+	var keys []string
+	for _, period := range ranges {
+		for _, bucket := range entity.ReportVersionBuckets(period[0], period[1]) {
+			keys = append(keys, constants.ReportVersionKeyPrefix+bucket)
+		}
+	}
+
+	return cache.Version(ctx, store, keys...)
+}
+
+// bumpReportVersion retires the cached reports that cover the store-local day
+// of transactionAt.
+func bumpReportVersion(ctx context.Context, store cache.Cache, transactionAt time.Time, location *time.Location) error {
+	var keys []string
+	for _, bucket := range entity.SaleVersionBuckets(transactionAt, location) {
+		keys = append(keys, constants.ReportVersionKeyPrefix+bucket)
+	}
+	return cache.RetireVersion(ctx, store, keys...)
+}
@@ -0,0 +1,292 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"snack-store-api/internal/constants"
+	"snack-store-api/internal/entity"
+	"snack-store-api/internal/messages"
+	"snack-store-api/internal/model"
+	"snack-store-api/internal/model/converter"
+	"snack-store-api/internal/repository"
+	"snack-store-api/internal/utils"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ShiftUseCase struct {
+	DB              *gorm.DB
+	Log             *logrus.Logger
+	ShiftRepository *repository.ShiftRepository
+}
+
+func NewShiftUseCase(
+	db *gorm.DB,
+	logger *logrus.Logger,
+	shiftRepository *repository.ShiftRepository,
+) *ShiftUseCase {
+	return &ShiftUseCase{
+		DB:              db,
+		Log:             logger,
+		ShiftRepository: shiftRepository,
+	}
+}
+
+func (c *ShiftUseCase) Open(
+	ctx context.Context,
+	request *model.OpenShiftRequest,
+) (*model.ShiftResponse, error) {
+	cashierName := strings.TrimSpace(request.CashierName)
+	if cashierName == "" {
+		return nil, utils.Error(messages.FailedValidationOccurred, http.StatusBadRequest, nil)
+	}
+
+	openedAt, err := parseOptionalDateTime(request.OpenedAt)
+	if err != nil {
+		c.Log.Warnf("Invalid opened_at format : %+v", err)
+		return nil, utils.Error(messages.FailedInputFormat, http.StatusBadRequest, err)
+	}
+
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	openShifts, err := c.ShiftRepository.FindOpen(tx, cashierName, "UPDATE")
+	if err != nil {
+		c.Log.Warnf("Failed to find open shift : %+v", err)
+		return nil, utils.Error(messages.InternalServerError, http.StatusInternalServerError, err)
+	}
+
+	if len(openShifts) > 0 {
+		return nil, utils.Error(messages.ErrShiftAlreadyOpen, http.StatusConflict, nil)
+	}
+
+	shift := entity.Shift{
+		CashierName:  cashierName,
+		Status:       entity.ShiftStatusOpen,
+		OpeningFloat: *request.OpeningFloat,
+		OpenedAt:     openedAt,
+	}
+
+	if err := c.ShiftRepository.Create(tx, &shift); err != nil {
+		c.Log.Warnf("Failed to open shift : %+v", err)
+		return nil, utils.Error(messages.InternalServerError, http.StatusInternalServerError, err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed to commit transaction : %+v", err)
+		return nil, utils.Error(messages.InternalServerError, http.StatusInternalServerError, err)
+	}
+
+	return converter.ShiftToResponse(&shift), nil
+}
+
+func (c *ShiftUseCase) Active(
+	ctx context.Context,
+	request *model.GetActiveShiftRequest,
+) (*model.ShiftResponse, error) {
+	cashierName := strings.TrimSpace(request.CashierName)
+	shifts, err := c.ShiftRepository.FindOpen(c.DB.WithContext(ctx), cashierName, "")
+	if err != nil {
+		c.Log.Warnf("Failed to find open shift : %+v", err)
+		return nil, utils.Error(messages.InternalServerError, http.StatusInternalServerError, err)
+	}
+
+	switch len(shifts) {
+	case 0:
+		return nil, utils.Error(messages.ErrNoOpenShift, http.StatusNotFound, nil)
+	case 1:
+		return converter.ShiftToResponse(&shifts[0]), nil
+	default:
+		return nil, utils.Error(messages.ErrShiftAmbiguous, http.StatusConflict, nil)
+	}
+}
+
+func (c *ShiftUseCase) Close(
+	ctx context.Context,
+	request *model.CloseShiftRequest,
+) (*model.ShiftReportResponse, error) {
+	shiftID, err := uuid.Parse(strings.TrimSpace(request.ID))
+	if err != nil {
+		c.Log.Warnf("Invalid shift id : %+v", err)
+		return nil, utils.Error(messages.ErrInvalidIDFormat, http.StatusBadRequest, err)
+	}
+
+	closedAt, err := parseOptionalDateTime(request.ClosedAt)
+	if err != nil {
+		c.Log.Warnf("Invalid closed_at format : %+v", err)
+		return nil, utils.Error(messages.FailedInputFormat, http.StatusBadRequest, err)
+	}
+
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	var shift entity.Shift
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", shiftID).
+		Take(&shift).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.Error(messages.StatusNotFound, http.StatusNotFound, err)
+		}
+		c.Log.Warnf("Failed to lock shift : %+v", err)
+		return nil, utils.Error(messages.InternalServerError, http.StatusInternalServerError, err)
+	}
+
+	if shift.Status != entity.ShiftStatusOpen {
+		return nil, utils.Error(messages.ErrShiftClosed, http.StatusConflict, nil)
+	}
+
+	if closedAt.Before(shift.OpenedAt) {
+		return nil, utils.Error(messages.InvalidRequestData, http.StatusBadRequest, nil)
+	}
+
+	sales, err := c.ShiftRepository.GetSalesSummary(tx, shift.ID)
+	if err != nil {
+		c.Log.Warnf("Failed to get shift sales : %+v", err)
+		return nil, utils.Error(messages.InternalServerError, http.StatusInternalServerError, err)
+	}
+
+	expected, _ := entity.ReconcileShift(shift.OpeningFloat, sales.TotalSales, nil)
+	expectedCash := int(expected)
+	countedCash := *request.CountedCash
+
+	shift.Status = entity.ShiftStatusClosed
+	shift.ExpectedCash = &expectedCash
+	shift.CountedCash = &countedCash
+	shift.ClosedAt = &closedAt
+	shift.Notes = strings.TrimSpace(request.Notes)
+
+	if err := c.ShiftRepository.Update(tx, &shift); err != nil {
+		c.Log.Warnf("Failed to close shift : %+v", err)
+		return nil, utils.Error(messages.InternalServerError, http.StatusInternalServerError, err)
+	}
+
+	redemptions, err := c.ShiftRepository.GetRedemptionSummary(tx, shift.ID)
+	if err != nil {
+		c.Log.Warnf("Failed to get shift redemptions : %+v", err)
+		return nil, utils.Error(messages.InternalServerError, http.StatusInternalServerError, err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed to commit transaction : %+v", err)
+		return nil, utils.Error(messages.InternalServerError, http.StatusInternalServerError, err)
+	}
+
+	return buildShiftReport(&shift, sales, redemptions), nil
+}
+
+func (c *ShiftUseCase) Report(
+	ctx context.Context,
+	request *model.GetShiftRequest,
+) (*model.ShiftReportResponse, error) {
+	shiftID, err := uuid.Parse(strings.TrimSpace(request.ID))
+	if err != nil {
+		c.Log.Warnf("Invalid shift id : %+v", err)
+		return nil, utils.Error(messages.ErrInvalidIDFormat, http.StatusBadRequest, err)
+	}
+
+	db := c.DB.WithContext(ctx)
+
+	var shift entity.Shift
+	if err := c.ShiftRepository.FindById(db, &shift, shiftID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.Error(messages.StatusNotFound, http.StatusNotFound, err)
+		}
+		c.Log.Warnf("Failed to find shift : %+v", err)
+		return nil, utils.Error(messages.InternalServerError, http.StatusInternalServerError, err)
+	}
+
+	sales, err := c.ShiftRepository.GetSalesSummary(db, shift.ID)
+	if err != nil {
+		c.Log.Warnf("Failed to get shift sales : %+v", err)
+		return nil, utils.Error(messages.InternalServerError, http.StatusInternalServerError, err)
+	}
+
+	redemptions, err := c.ShiftRepository.GetRedemptionSummary(db, shift.ID)
+	if err != nil {
+		c.Log.Warnf("Failed to get shift redemptions : %+v", err)
+		return nil, utils.Error(messages.InternalServerError, http.StatusInternalServerError, err)
+	}
+
+	return buildShiftReport(&shift, sales, redemptions), nil
+}
+
+func buildShiftReport(
+	shift *entity.Shift,
+	sales repository.ShiftSalesRow,
+	redemptions repository.ShiftRedemptionRow,
+) *model.ShiftReportResponse {
+	expectedCash, difference := entity.ReconcileShift(shift.OpeningFloat, sales.TotalSales, shift.CountedCash)
+	response := &model.ShiftReportResponse{
+		Shift:            converter.ShiftToResponse(shift),
+		TotalTransaction: sales.TotalTransaction,
+		TotalQtySold:     sales.TotalQty,
+		TotalSales:       sales.TotalSales,
+		TotalTax:         sales.TotalTax,
+		TotalRedemption:  redemptions.TotalRedemption,
+		TotalQtyRedeemed: redemptions.TotalQty,
+		TotalPointsSpent: redemptions.TotalPointsSpent,
+		ExpectedCash:     expectedCash,
+		Difference:       difference,
+	}
+
+	if shift.CountedCash != nil {
+		counted := int64(*shift.CountedCash)
+		response.CountedCash = &counted
+	}
+
+	return response
+}
+
+// activeShiftID picks the shift a sale or redemption belongs to and locks it
+// for share so it cannot be closed while the record is written. A named
+// cashier must have an open shift; without a name the only open shift is
+// used. Several open shifts are refused as ambiguous only when a shift is
+// required, otherwise the record is left unassigned so clients that never
+// send a cashier name keep working.
+func activeShiftID(
+	tx *gorm.DB,
+	log *logrus.Logger,
+	shiftRepository *repository.ShiftRepository,
+	cashierName string,
+	required bool,
+) (*uuid.UUID, error) {
+	if shiftRepository == nil {
+		return nil, nil
+	}
+
+	cashierName = strings.TrimSpace(cashierName)
+	shifts, err := shiftRepository.FindOpen(tx, cashierName, "SHARE")
+	if err != nil {
+		log.Warnf("Failed to find open shift : %+v", err)
+		return nil, utils.Error(messages.InternalServerError, http.StatusInternalServerError, err)
+	}
+
+	switch {
+	case len(shifts) == 1:
+		return &shifts[0].ID, nil
+	case len(shifts) > 1 && !required && cashierName == "":
+		return nil, nil
+	case len(shifts) > 1:
+		return nil, utils.Error(messages.ErrShiftAmbiguous, http.StatusConflict, nil)
+	case required || cashierName != "":
+		return nil, utils.Error(messages.ErrNoOpenShift, http.StatusConflict, nil)
+	default:
+		return nil, nil
+	}
+}
+
+func parseOptionalDateTime(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Now(), nil
+	}
+
+	return time.Parse(constants.DateTimeLayout, value)
+}
@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"errors"
+	"io"
 	"net/http"
 	"strings"
 	"time"
@@ -28,7 +29,13 @@ type TransactionUseCase struct {
 	CustomerRepository    *repository.CustomerRepository
 	ProductRepository     *repository.ProductRepository
 	TransactionRepository *repository.TransactionRepository
-	Cache                 cache.Cache
+	ShiftRepository       *repository.ShiftRepository
+	// SummaryRepository keeps daily_sales_summary in step with new transactions.
+	SummaryRepository *repository.DailySalesSummaryRepository
+	Cache             cache.Cache
+	TaxPolicy         *entity.TaxPolicy
+	Location          *time.Location
+	ShiftRequired     bool
 }
 
 func NewTransactionUseCase(
@@ -37,7 +44,12 @@ func NewTransactionUseCase(
 	customerRepository *repository.CustomerRepository,
 	productRepository *repository.ProductRepository,
 	transactionRepository *repository.TransactionRepository,
+	shiftRepository *repository.ShiftRepository,
+	summaryRepository *repository.DailySalesSummaryRepository,
 	cacheStore cache.Cache,
+	taxPolicy *entity.TaxPolicy,
+	location *time.Location,
+	shiftRequired bool,
 ) *TransactionUseCase {
 	return &TransactionUseCase{
 		DB:                    db,
@@ -45,7 +57,12 @@ func NewTransactionUseCase(
 		CustomerRepository:    customerRepository,
 		ProductRepository:     productRepository,
 		TransactionRepository: transactionRepository,
+		ShiftRepository:       shiftRepository,
+		SummaryRepository:     summaryRepository,
 		Cache:                 cacheStore,
+		TaxPolicy:             taxPolicy,
+		Location:              location,
+		ShiftRequired:         shiftRequired,
 	}
 }
 
@@ -73,6 +90,11 @@ func (c *TransactionUseCase) Create(
 	tx := c.DB.WithContext(ctx).Begin()
 	defer tx.Rollback()
 
+	shiftID, err := activeShiftID(tx, c.Log, c.ShiftRepository, request.CashierName, c.ShiftRequired)
+	if err != nil {
+		return nil, err
+	}
+
 	var product entity.Product
 	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
 		Where("id = ?", productID).
@@ -94,7 +116,8 @@ func (c *TransactionUseCase) Create(
 	}
 
 	unitPrice := product.Price
-	totalPrice := unitPrice * request.Qty
+	taxAmount, taxRate := c.TaxPolicy.Calculate(product.Type, unitPrice*request.Qty)
+	totalPrice := taxAmount.Gross
 	pointsEarned := entity.PointsEarned(totalPrice)
 
 	product.StockQty -= request.Qty
@@ -110,13 +133,30 @@ func (c *TransactionUseCase) Create(
 		return nil, utils.Error(messages.InternalServerError, http.StatusInternalServerError, err)
 	}
 
+	localAt := transactionAt.In(c.Location)
+	receiptDate := time.Date(localAt.Year(), localAt.Month(), localAt.Day(), 0, 0, 0, 0, time.UTC)
+	receiptSequence, err := c.TransactionRepository.NextReceiptSequence(tx, receiptDate)
+	if err != nil {
+		c.Log.Warnf("Failed to generate receipt number : %+v", err)
+		return nil, utils.Error(messages.InternalServerError, http.StatusInternalServerError, err)
+	}
+
+	receiptNo := entity.FormatReceiptNo(receiptDate, receiptSequence)
+	pointsBalance := customer.Points
+
 	transaction := entity.Transaction{
+		ReceiptNo:     &receiptNo,
 		CustomerID:    customer.ID,
 		ProductID:     product.ID,
+		ShiftID:       shiftID,
 		Qty:           request.Qty,
 		UnitPrice:     unitPrice,
 		TotalPrice:    totalPrice,
+		NetAmount:     taxAmount.Net,
+		TaxAmount:     taxAmount.Tax,
+		TaxRateBps:    taxRate,
 		PointsEarned:  pointsEarned,
+		PointsBalance: &pointsBalance,
 		TransactionAt: transactionAt,
 	}
 
@@ -125,6 +165,11 @@ func (c *TransactionUseCase) Create(
 		return nil, utils.Error(messages.InternalServerError, http.StatusInternalServerError, err)
 	}
 
+	if err := c.SummaryRepository.Increment(tx, &transaction); err != nil {
+		c.Log.Warnf("Failed to update daily sales summary : %+v", err)
+		return nil, utils.Error(messages.InternalServerError, http.StatusInternalServerError, err)
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		c.Log.Warnf("Failed to commit transaction : %+v", err)
 		return nil, utils.Error(messages.InternalServerError, http.StatusInternalServerError, err)
@@ -133,7 +178,7 @@ func (c *TransactionUseCase) Create(
 	transaction.Customer = customer
 	transaction.Product = product
 
-	c.invalidateCaches(ctx, &product)
+	c.invalidateCaches(ctx, &product, transaction.TransactionAt)
 
 	return converter.TransactionToResponse(&transaction), nil
 }
@@ -142,40 +187,21 @@ func (c *TransactionUseCase) List(
 	ctx context.Context,
 	request *model.GetTransactionRequest,
 ) ([]*model.TransactionResponse, model.PageMetadata, error) {
-	startDate, err := time.Parse(constants.DateLayout, strings.TrimSpace(request.Start))
+	filter, err := c.buildFilter(request)
 	if err != nil {
-		c.Log.Warnf("Invalid start date : %+v", err)
-		return nil, model.PageMetadata{}, utils.Error(messages.FailedInputFormat, http.StatusBadRequest, err)
+		return nil, model.PageMetadata{}, err
 	}
 
-	endDate, err := time.Parse(constants.DateLayout, strings.TrimSpace(request.End))
-	if err != nil {
-		c.Log.Warnf("Invalid end date : %+v", err)
-		return nil, model.PageMetadata{}, utils.Error(messages.FailedInputFormat, http.StatusBadRequest, err)
-	}
-
-	if endDate.Before(startDate) {
-		return nil, model.PageMetadata{}, utils.Error(messages.InvalidRequestData, http.StatusBadRequest, nil)
-	}
-
-	endDate = endDate.AddDate(0, 0, 1)
-
 	db := c.DB.WithContext(ctx)
 
-	totalItem, err := c.TransactionRepository.CountByDateRange(db, startDate, endDate)
+	totalItem, err := c.TransactionRepository.CountByFilter(db, filter)
 	if err != nil {
 		c.Log.Warnf("Failed to count transactions : %+v", err)
 		return nil, model.PageMetadata{}, utils.Error(messages.InternalServerError, http.StatusInternalServerError, err)
 	}
 
 	offset := (request.Page - 1) * request.PageSize
-	transactions, err := c.TransactionRepository.FindByDateRange(
-		db,
-		startDate,
-		endDate,
-		request.PageSize,
-		offset,
-	)
+	transactions, err := c.TransactionRepository.FindByFilter(db, filter, request.PageSize, offset)
 	if err != nil {
 		c.Log.Warnf("Failed to query transactions : %+v", err)
 		return nil, model.PageMetadata{}, utils.Error(messages.InternalServerError, http.StatusInternalServerError, err)
@@ -190,7 +216,167 @@ func (c *TransactionUseCase) List(
 	return responses, paging, nil
 }
 
-func (c *TransactionUseCase) invalidateCaches(ctx context.Context, product *entity.Product) {
+// ListByCursor pages transactions with a keyset cursor instead of an offset, so
+// deep pages stay cheap and rows inserted between requests are not skipped or repeated.
+func (c *TransactionUseCase) ListByCursor(
+	ctx context.Context,
+	request *model.GetTransactionRequest,
+) ([]*model.TransactionResponse, model.CursorMetadata, error) {
+	filter, err := c.buildFilter(request)
+	if err != nil {
+		return nil, model.CursorMetadata{}, err
+	}
+
+	after, err := decodeKeyset(c.Log, request.Cursor)
+	if err != nil {
+		return nil, model.CursorMetadata{}, err
+	}
+
+	db := c.DB.WithContext(ctx)
+
+	var totalItem *int64
+	if request.WithCount {
+		count, err := c.TransactionRepository.CountByFilter(db, filter)
+		if err != nil {
+			c.Log.Warnf("Failed to count transactions : %+v", err)
+			return nil, model.CursorMetadata{}, utils.Error(messages.InternalServerError, http.StatusInternalServerError, err)
+		}
+		totalItem = &count
+	}
+
+	filter.After = after
+	transactions, err := c.TransactionRepository.FindByFilter(db, filter, request.PageSize+1, 0)
+	if err != nil {
+		c.Log.Warnf("Failed to query transactions : %+v", err)
+		return nil, model.CursorMetadata{}, utils.Error(messages.InternalServerError, http.StatusInternalServerError, err)
+	}
+
+	nextCursor := ""
+	if len(transactions) > request.PageSize {
+		transactions = transactions[:request.PageSize]
+		last := transactions[len(transactions)-1]
+		nextCursor = utils.EncodeCursor(last.TransactionAt, last.ID)
+	}
+
+	responses := make([]*model.TransactionResponse, 0, len(transactions))
+	for i := range transactions {
+		responses = append(responses, converter.TransactionToResponse(&transactions[i]))
+	}
+
+	return responses, utils.BuildCursorMetadata(request.PageSize, nextCursor, totalItem), nil
+}
+
+// Export streams the transactions matching request to out as CSV or XLSX.
+func (c *TransactionUseCase) Export(
+	ctx context.Context,
+	request *model.GetTransactionRequest,
+	format string,
+	locale string,
+	out io.Writer,
+) error {
+	filter, err := c.buildFilter(request)
+	if err != nil {
+		return err
+	}
+
+	writer, err := newExportWriter(c.Log, format, locale, out, c.Location)
+	if err != nil {
+		return err
+	}
+	defer writer.Abort()
+
+	if err := writeTransactionSheet(c.DB.WithContext(ctx), c.TransactionRepository, filter, writer); err != nil {
+		c.Log.Warnf("Failed to export transactions : %+v", err)
+		return utils.Error(messages.ErrExport, http.StatusInternalServerError, err)
+	}
+
+	if err := writer.Close(); err != nil {
+		c.Log.Warnf("Failed to finish transactions export : %+v", err)
+		return utils.Error(messages.ErrExport, http.StatusInternalServerError, err)
+	}
+
+	return nil
+}
+
+func (c *TransactionUseCase) buildFilter(request *model.GetTransactionRequest) (*repository.TransactionFilter, error) {
+	startDate, endDate, err := parseStoreDateRange(c.Log, strings.TrimSpace(request.Start), strings.TrimSpace(request.End), c.Location)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.MinTotal != nil && request.MaxTotal != nil && *request.MinTotal > *request.MaxTotal {
+		return nil, utils.Error(messages.InvalidRequestData, http.StatusBadRequest, nil)
+	}
+
+	filter := &repository.TransactionFilter{
+		StartDate:    startDate,
+		EndDate:      endDate,
+		CustomerName: strings.TrimSpace(request.CustomerName),
+		Flavor:       request.Flavor,
+		Size:         request.Size,
+		MinTotal:     request.MinTotal,
+		MaxTotal:     request.MaxTotal,
+		SortAsc:      request.Sort == constants.SortAsc,
+	}
+
+	if request.ProductID != "" {
+		productID, err := uuid.Parse(request.ProductID)
+		if err != nil {
+			c.Log.Warnf("Invalid product_id : %+v", err)
+			return nil, utils.Error(messages.ErrInvalidIDFormat, http.StatusBadRequest, err)
+		}
+		filter.ProductID = &productID
+	}
+
+	return filter, nil
+}
+
+func (c *TransactionUseCase) Detail(
+	ctx context.Context,
+	request *model.GetTransactionDetailRequest,
+) (*model.TransactionResponse, error) {
+	transaction, err := c.findDetail(ctx, request.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return converter.TransactionToResponse(transaction), nil
+}
+
+func (c *TransactionUseCase) Receipt(
+	ctx context.Context,
+	request *model.GetTransactionReceiptRequest,
+) (*model.ReceiptResponse, error) {
+	transaction, err := c.findDetail(ctx, request.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return converter.TransactionToReceipt(transaction), nil
+}
+
+func (c *TransactionUseCase) findDetail(ctx context.Context, id string) (*entity.Transaction, error) {
+	transactionID, err := uuid.Parse(strings.TrimSpace(id))
+	if err != nil {
+		c.Log.Warnf("Invalid transaction id : %+v", err)
+		return nil, utils.Error(messages.ErrInvalidIDFormat, http.StatusBadRequest, err)
+	}
+
+	var transaction entity.Transaction
+	if err := c.TransactionRepository.FindDetailById(c.DB.WithContext(ctx), &transaction, transactionID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.Error(messages.StatusNotFound, http.StatusNotFound, err)
+		}
+		c.Log.Warnf("Failed to find transaction : %+v", err)
+		return nil, utils.Error(messages.InternalServerError, http.StatusInternalServerError, err)
+	}
+
+	return &transaction, nil
+}
+
+// invalidateCaches drops the product list of the sold product and retires only
+// the cached reports whose period includes transactionAt.
+func (c *TransactionUseCase) invalidateCaches(ctx context.Context, product *entity.Product, transactionAt time.Time) {
 	if c.Cache == nil || product == nil {
 		return
 	}
@@ -200,7 +386,7 @@ func (c *TransactionUseCase) invalidateCaches(ctx context.Context, product *enti
 		c.Log.Warnf("Failed to invalidate product cache : %+v", err)
 	}
 
-	if err := c.Cache.DelByPrefix(ctx, constants.ReportCacheKeyPrefix); err != nil {
+	if err := bumpReportVersion(ctx, c.Cache, transactionAt, c.Location); err != nil {
 		c.Log.Warnf("Failed to invalidate report cache : %+v", err)
 	}
 }
@@ -219,8 +405,9 @@ func (c *TransactionUseCase) findOrCreateCustomer(tx *gorm.DB, name string) (ent
 	}
 
 	customer = entity.Customer{
-		Name:   name,
-		Points: 0,
+		Name:          name,
+		Points:        0,
+		OpeningPoints: new(int),
 	}
 
 	if err := tx.Create(&customer).Error; err == nil {
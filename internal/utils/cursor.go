@@ -0,0 +1,53 @@
+package utils
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"time"
+
+	"snack-store-api/internal/model"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// EncodeCursor builds an opaque keyset cursor from the last row of a page.
+func EncodeCursor(at time.Time, id uuid.UUID) string {
+	raw := at.UTC().Format(time.RFC3339Nano) + "|" + id.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func DecodeCursor(cursor string) (time.Time, uuid.UUID, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(strings.TrimSpace(cursor))
+	if err != nil {
+		return time.Time{}, uuid.Nil, ErrInvalidCursor
+	}
+
+	atStr, idStr, found := strings.Cut(string(raw), "|")
+	if !found {
+		return time.Time{}, uuid.Nil, ErrInvalidCursor
+	}
+
+	at, err := time.Parse(time.RFC3339Nano, atStr)
+	if err != nil {
+		return time.Time{}, uuid.Nil, ErrInvalidCursor
+	}
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		return time.Time{}, uuid.Nil, ErrInvalidCursor
+	}
+
+	return at, id, nil
+}
+
+func BuildCursorMetadata(pageSize int, nextCursor string, totalItem *int64) model.CursorMetadata {
+	return model.CursorMetadata{
+		PageSize:   pageSize,
+		NextCursor: nextCursor,
+		HasNext:    nextCursor != "",
+		TotalItem:  totalItem,
+	}
+}
@@ -35,6 +35,20 @@ func ParsePagination(
 	return page, pageSize, nil
 }
 
+func ParseOptionalInt(param string) (*int, error) {
+	trimmed := strings.TrimSpace(param)
+	if trimmed == "" {
+		return nil, nil
+	}
+
+	parsed, err := strconv.Atoi(trimmed)
+	if err != nil {
+		return nil, err
+	}
+
+	return &parsed, nil
+}
+
 func BuildPageMetadata(page int, pageSize int, totalItem int64) model.PageMetadata {
 	totalPage := int64(0)
 	if pageSize > 0 && totalItem > 0 {
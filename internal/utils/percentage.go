@@ -0,0 +1,13 @@
+package utils
+
+import "math"
+
+// Percentage returns part as a percentage of total rounded to two decimals,
+// or 0 when total is zero.
+func Percentage(part, total int64) float64 {
+	if total == 0 {
+		return 0
+	}
+
+	return math.Round(float64(part)*10000/float64(total)) / 100
+}
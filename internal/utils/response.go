@@ -73,6 +73,18 @@ func SuccessWithPaginationResponse[T any](
 	}
 }
 
+func SuccessWithCursorResponse[T any](
+	message string,
+	data []T,
+	cursor model.CursorMetadata,
+) model.WebResponse[[]T] {
+	return model.WebResponse[[]T]{
+		Message: message,
+		Data:    data,
+		Cursor:  &cursor,
+	}
+}
+
 func errorCodeFromStatus(status int) string {
 	switch status {
 	case http.StatusBadRequest:
@@ -0,0 +1,245 @@
+package test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"snack-store-api/internal/archive"
+)
+
+type archiveRow struct {
+	ID    int
+	Name  string
+	Stock int
+}
+
+func buildArchive(t *testing.T, tables map[string][]archiveRow, tamper func(*archive.Manifest)) []byte {
+	t.Helper()
+
+	dir := t.TempDir()
+	manifest := &archive.Manifest{
+		FormatVersion: archive.FormatVersion,
+		SchemaVersion: 2,
+		CreatedAt:     time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	for _, name := range []string{"customers", "products"} {
+		entry := archive.TableEntry{Name: name, File: archive.FileName(name)}
+		file, err := os.Create(filepath.Join(dir, entry.File))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		writer := archive.NewTableWriter(file)
+		for _, row := range tables[name] {
+			if err := writer.Write(row); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if entry.Rows, entry.SHA256, err = writer.Close(); err != nil {
+			t.Fatal(err)
+		}
+		file.Close()
+		manifest.Tables = append(manifest.Tables, entry)
+	}
+
+	if tamper != nil {
+		tamper(manifest)
+	}
+
+	var buffer bytes.Buffer
+	writer := archive.NewWriter(&buffer)
+	if err := writer.WriteManifest(manifest); err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range manifest.Tables {
+		name := archive.FileName(entry.Name)
+		if err := writer.WriteFile(name, filepath.Join(dir, name), manifest.CreatedAt); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buffer.Bytes()
+}
+
+func readArchive(data []byte) (map[string][]archiveRow, error) {
+	reader, err := archive.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	result := map[string][]archiveRow{}
+	for {
+		entry, tableReader, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return result, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for {
+			var row archiveRow
+			err := tableReader.Decode(&row)
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			result[entry.Name] = append(result[entry.Name], row)
+		}
+	}
+}
+
+func TestArchiveRoundTrip(t *testing.T) {
+	tables := map[string][]archiveRow{
+		"customers": {{ID: 1, Name: "Fery"}, {ID: 2, Name: "Fenty"}},
+		"products":  {{ID: 10, Name: "Keripik Pangsit", Stock: 40}},
+	}
+
+	testCases := []struct {
+		name    string
+		tamper  func(*archive.Manifest)
+		wantErr string
+	}{
+		{
+			name: "intact",
+		},
+		{
+			name:    "checksum_mismatch",
+			tamper:  func(m *archive.Manifest) { m.Tables[1].SHA256 = strings.Repeat("0", 64) },
+			wantErr: "checksum",
+		},
+		{
+			name:    "row_count_mismatch",
+			tamper:  func(m *archive.Manifest) { m.Tables[0].Rows = 3 },
+			wantErr: "manifest says 3",
+		},
+		{
+			name:    "file_mismatch",
+			tamper:  func(m *archive.Manifest) { m.Tables[0].File = "clients.jsonl" },
+			wantErr: "manifest expects",
+		},
+		{
+			name:    "unsupported_format",
+			tamper:  func(m *archive.Manifest) { m.FormatVersion = archive.FormatVersion + 1 },
+			wantErr: "format version",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := readArchive(buildArchive(t, tables, tc.tamper))
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			for name, rows := range tables {
+				if len(result[name]) != len(rows) {
+					t.Fatalf("%s: expected %d rows, got %d", name, len(rows), len(result[name]))
+				}
+				for i := range rows {
+					if result[name][i] != rows[i] {
+						t.Fatalf("%s row %d: expected %+v, got %+v", name, i, rows[i], result[name][i])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestManifestCheckTables(t *testing.T) {
+	entries := func(names ...string) []archive.TableEntry {
+		tables := make([]archive.TableEntry, len(names))
+		for i, name := range names {
+			tables[i] = archive.TableEntry{Name: name, File: archive.FileName(name)}
+		}
+		return tables
+	}
+
+	testCases := []struct {
+		name    string
+		tables  []archive.TableEntry
+		wantErr bool
+	}{
+		{name: "expected_tables", tables: entries(archive.Tables...)},
+		{name: "missing_table", tables: entries("customers", "products", "shifts", "transactions", "redemptions"), wantErr: true},
+		{name: "extra_table", tables: entries(append(slices.Clone(archive.Tables), "stock_movements")...), wantErr: true},
+		{name: "wrong_order", tables: entries("products", "customers", "shifts", "transactions", "redemptions", "receipt_sequences"), wantErr: true},
+		{name: "empty", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			manifest := &archive.Manifest{Tables: tc.tables}
+			err := manifest.CheckTables()
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("expected error %v, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestManifestCheckTotals(t *testing.T) {
+	manifest := &archive.Manifest{
+		Totals: archive.Totals{CustomerPoints: 275, ProductStock: 120, PointsEarned: 80, PointsSpent: 200},
+	}
+
+	testCases := []struct {
+		name    string
+		totals  archive.Totals
+		wantErr []string
+	}{
+		{
+			name:   "matching",
+			totals: manifest.Totals,
+		},
+		{
+			name:    "stock_differs",
+			totals:  archive.Totals{CustomerPoints: 275, ProductStock: 119, PointsEarned: 80, PointsSpent: 200},
+			wantErr: []string{"product_stock 119, expected 120"},
+		},
+		{
+			name:    "points_differ",
+			totals:  archive.Totals{CustomerPoints: 0, ProductStock: 120, PointsEarned: 80, PointsSpent: 0},
+			wantErr: []string{"customer_points 0, expected 275", "points_spent 0, expected 200"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := manifest.CheckTotals(tc.totals)
+			if len(tc.wantErr) == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+			for _, want := range tc.wantErr {
+				if !strings.Contains(err.Error(), want) {
+					t.Fatalf("expected error containing %q, got %v", want, err)
+				}
+			}
+		})
+	}
+}
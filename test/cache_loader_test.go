@@ -0,0 +1,131 @@
+package test
+
+import (
+	"context"
+	"io"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"snack-store-api/internal/cache"
+
+	"github.com/sirupsen/logrus"
+)
+
+func newTestLoader(staleTTL time.Duration) *cache.Loader {
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+	return cache.NewLoader(cache.NewMemoryCache(10), log, staleTTL)
+}
+
+func TestLoaderCoalescesConcurrentMisses(t *testing.T) {
+	loader := newTestLoader(0)
+	release := make(chan struct{})
+	var calls int32
+
+	load := func(context.Context) (string, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return "value", nil
+	}
+
+	const callers = 20
+	var wg sync.WaitGroup
+	results := make([]string, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], _ = loader.Load(context.Background(), "key", time.Minute, load)
+		}(i)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Fatalf("expected 1 load, got %d", got)
+	}
+	for i, result := range results {
+		if result != "value" {
+			t.Fatalf("caller %d: expected value, got %q", i, result)
+		}
+	}
+}
+
+func TestLoaderServesStaleWhileRevalidating(t *testing.T) {
+	loader := newTestLoader(time.Minute)
+	ctx := context.Background()
+	var version int32
+
+	load := func(context.Context) (string, error) {
+		if atomic.AddInt32(&version, 1) == 1 {
+			return "v1", nil
+		}
+		return "v2", nil
+	}
+
+	if got, _ := loader.Load(ctx, "key", 10*time.Millisecond, load); got != "v1" {
+		t.Fatalf("expected v1, got %q", got)
+	}
+	time.Sleep(30 * time.Millisecond)
+
+	if got, _ := loader.Load(ctx, "key", 10*time.Millisecond, load); got != "v1" {
+		t.Fatalf("expected stale v1, got %q", got)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if got, _ := loader.Load(ctx, "key", time.Minute, load); got == "v2" {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("expected background refresh to store v2")
+}
+
+func TestLoaderWithoutStaleReloadsExpired(t *testing.T) {
+	loader := newTestLoader(0)
+	ctx := context.Background()
+	var calls int32
+
+	load := func(context.Context) (string, error) {
+		atomic.AddInt32(&calls, 1)
+		return "value", nil
+	}
+
+	_, _ = loader.Load(ctx, "key", 10*time.Millisecond, load)
+	_, _ = loader.Load(ctx, "key", 10*time.Millisecond, load)
+	time.Sleep(30 * time.Millisecond)
+	_, _ = loader.Load(ctx, "key", 10*time.Millisecond, load)
+
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Fatalf("expected 2 loads, got %d", got)
+	}
+}
+
+func TestLoaderTreatsLegacyValueAsMiss(t *testing.T) {
+	store := cache.NewMemoryCache(10)
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+	loader := cache.NewLoader(store, log, time.Minute)
+
+	ctx := context.Background()
+	if err := store.Set(ctx, "key", `{"total_income":100}`, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+
+	var calls int32
+	value, err := loader.Load(ctx, "key", time.Minute, func(context.Context) (string, error) {
+		atomic.AddInt32(&calls, 1)
+		return "fresh", nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if value != "fresh" || atomic.LoadInt32(&calls) != 1 {
+		t.Fatalf("expected a reload for a legacy value, got %q after %d loads", value, calls)
+	}
+}
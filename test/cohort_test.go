@@ -0,0 +1,115 @@
+package test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"snack-store-api/internal/model/converter"
+	"snack-store-api/internal/repository"
+)
+
+func TestCohortActivityToResponse(t *testing.T) {
+	month := func(year int, month time.Month) time.Time {
+		return time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	}
+	offset := func(n int) *int {
+		return &n
+	}
+
+	type retention struct {
+		Month  string
+		Active int64
+		Rate   float64
+	}
+	type cohort struct {
+		Cohort    string
+		Size      int64
+		Retention []retention
+	}
+
+	testCases := []struct {
+		name     string
+		rows     []repository.CohortActivityRow
+		months   int
+		now      time.Time
+		expected []cohort
+	}{
+		{
+			name:     "no_cohorts",
+			rows:     nil,
+			months:   3,
+			now:      month(2025, 6),
+			expected: []cohort{},
+		},
+		{
+			name: "zero_fills_missing_offsets",
+			rows: []repository.CohortActivityRow{
+				{CohortMonth: month(2025, 1), CohortSize: 4, MonthOffset: offset(0), ActiveCustomer: 3},
+				{CohortMonth: month(2025, 1), CohortSize: 4, MonthOffset: offset(2), ActiveCustomer: 1},
+			},
+			months: 3,
+			now:    month(2025, 6),
+			expected: []cohort{
+				{Cohort: "2025-01", Size: 4, Retention: []retention{
+					{Month: "2025-01", Active: 3, Rate: 75},
+					{Month: "2025-02", Active: 0, Rate: 0},
+					{Month: "2025-03", Active: 1, Rate: 25},
+					{Month: "2025-04", Active: 0, Rate: 0},
+				}},
+			},
+		},
+		{
+			name: "cohort_without_purchases",
+			rows: []repository.CohortActivityRow{
+				{CohortMonth: month(2025, 2), CohortSize: 2, MonthOffset: nil, ActiveCustomer: 0},
+			},
+			months: 1,
+			now:    month(2025, 6),
+			expected: []cohort{
+				{Cohort: "2025-02", Size: 2, Retention: []retention{
+					{Month: "2025-02", Active: 0, Rate: 0},
+					{Month: "2025-03", Active: 0, Rate: 0},
+				}},
+			},
+		},
+		{
+			name: "future_months_omitted",
+			rows: []repository.CohortActivityRow{
+				{CohortMonth: month(2025, 4), CohortSize: 5, MonthOffset: offset(0), ActiveCustomer: 5},
+				{CohortMonth: month(2025, 4), CohortSize: 5, MonthOffset: offset(1), ActiveCustomer: 2},
+				{CohortMonth: month(2025, 5), CohortSize: 1, MonthOffset: offset(0), ActiveCustomer: 1},
+			},
+			months: 6,
+			now:    time.Date(2025, 5, 20, 10, 0, 0, 0, time.UTC),
+			expected: []cohort{
+				{Cohort: "2025-04", Size: 5, Retention: []retention{
+					{Month: "2025-04", Active: 5, Rate: 100},
+					{Month: "2025-05", Active: 2, Rate: 40},
+				}},
+				{Cohort: "2025-05", Size: 1, Retention: []retention{
+					{Month: "2025-05", Active: 1, Rate: 100},
+				}},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := make([]cohort, 0)
+			for _, c := range converter.CohortActivityToResponse(tc.rows, tc.months, tc.now) {
+				item := cohort{Cohort: c.Cohort, Size: c.Size}
+				for i, r := range c.Retention {
+					if r.MonthOffset != i {
+						t.Fatalf("cohort %s: expected offset %d, got %d", c.Cohort, i, r.MonthOffset)
+					}
+					item.Retention = append(item.Retention, retention{Month: r.Month, Active: r.ActiveCustomer, Rate: r.Rate})
+				}
+				got = append(got, item)
+			}
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Fatalf("expected %+v, got %+v", tc.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"snack-store-api/internal/command"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+)
+
+type commandRun struct {
+	env         string
+	interactive bool
+	input       string
+	args        []string
+}
+
+// runCommand executes args against an executor whose database is never
+// reachable, and reports the exit code, stderr and whether a connection was
+// attempted.
+func runCommand(run commandRun) (int, string, bool) {
+	config := viper.New()
+	config.Set("APP_ENV", run.env)
+	config.Set("DB_NAME", "snack_store")
+	config.Set("DROP_TABLE_NAMES", "transactions,customers")
+
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+
+	var stderr bytes.Buffer
+	connected := false
+
+	executor := command.NewCommandExecutor(config, log)
+	executor.Connect = func() (*gorm.DB, error) {
+		connected = true
+		return nil, errors.New("connection refused")
+	}
+	executor.In = strings.NewReader(run.input)
+	executor.Out = io.Discard
+	executor.Err = &stderr
+	executor.IsTerminal = func() bool {
+		return run.interactive
+	}
+
+	code := executor.Execute(run.args)
+	return code, stderr.String(), connected
+}
+
+func TestCommandExitCodes(t *testing.T) {
+	testCases := []struct {
+		name      string
+		run       commandRun
+		code      int
+		connected bool
+	}{
+		{name: "unknown_command", run: commandRun{args: []string{"bogus"}}, code: command.ExitUsage},
+		{name: "group_without_subcommand", run: commandRun{args: []string{"points"}}, code: command.ExitUsage},
+		{name: "unknown_flag", run: commandRun{args: []string{"points", "recalc", "--force"}}, code: command.ExitUsage},
+		{name: "missing_argument", run: commandRun{args: []string{"import"}}, code: command.ExitUsage},
+		{
+			name: "recalc_refused_in_production",
+			run:  commandRun{env: "production", args: []string{"points", "recalc", "--yes"}},
+			code: command.ExitRefused,
+		},
+		{
+			name:      "recalc_dry_run_allowed_in_production",
+			run:       commandRun{env: "production", args: []string{"points", "recalc", "--dry-run"}},
+			code:      command.ExitFailure,
+			connected: true,
+		},
+		{
+			name:      "recalc_allowed_in_production_with_override",
+			run:       commandRun{env: "production", args: []string{"points", "recalc", "--yes", "--allow-production"}},
+			code:      command.ExitFailure,
+			connected: true,
+		},
+		{
+			name: "drop_refused_in_production",
+			run:  commandRun{env: "production", args: []string{"db", "drop", "--yes"}},
+			code: command.ExitRefused,
+		},
+		{
+			name: "drop_needs_yes_when_not_interactive",
+			run:  commandRun{env: "development", args: []string{"db", "drop"}},
+			code: command.ExitRefused,
+		},
+		{
+			name: "drop_aborted_on_wrong_database_name",
+			run:  commandRun{env: "development", interactive: true, input: "other_db\n", args: []string{"db", "drop"}},
+			code: command.ExitRefused,
+		},
+		{
+			name:      "drop_confirmed_interactively",
+			run:       commandRun{env: "development", interactive: true, input: "snack_store\n", args: []string{"db", "drop"}},
+			code:      command.ExitFailure,
+			connected: true,
+		},
+		{
+			name:      "drop_confirmed_with_yes",
+			run:       commandRun{env: "development", args: []string{"db", "drop", "--yes"}},
+			code:      command.ExitFailure,
+			connected: true,
+		},
+		{
+			name: "tax_backfill_refused_in_production",
+			run:  commandRun{env: "production", args: []string{"tax", "backfill", "--yes"}},
+			code: command.ExitRefused,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			code, stderr, connected := runCommand(tc.run)
+			if code != tc.code {
+				t.Fatalf("expected exit code %d, got %d (stderr %q)", tc.code, code, stderr)
+			}
+			if connected != tc.connected {
+				t.Fatalf("expected connected=%v, got %v", tc.connected, connected)
+			}
+		})
+	}
+}
+
+func TestCommandConfirmationPrompt(t *testing.T) {
+	_, stderr, _ := runCommand(commandRun{
+		env:         "development",
+		interactive: true,
+		input:       "snack_store\n",
+		args:        []string{"db", "drop"},
+	})
+
+	if !strings.Contains(stderr, `database "snack_store"`) || !strings.Contains(stderr, "Type the database name") {
+		t.Fatalf("expected the confirmation prompt on stderr, got %q", stderr)
+	}
+}
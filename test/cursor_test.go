@@ -0,0 +1,46 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"snack-store-api/internal/utils"
+
+	"github.com/google/uuid"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	at := time.Date(2026, 3, 14, 9, 26, 53, 589793000, time.FixedZone("WIB", 7*3600))
+	id := uuid.MustParse("0f8e8a52-4a1d-4c3e-9a55-1b2c3d4e5f60")
+
+	gotAt, gotID, err := utils.DecodeCursor(utils.EncodeCursor(at, id))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !gotAt.Equal(at) {
+		t.Fatalf("expected time %v, got %v", at, gotAt)
+	}
+	if gotID != id {
+		t.Fatalf("expected id %s, got %s", id, gotID)
+	}
+}
+
+func TestDecodeCursorInvalid(t *testing.T) {
+	testCases := []struct {
+		name   string
+		cursor string
+	}{
+		{name: "not_base64", cursor: "!!!"},
+		{name: "missing_separator", cursor: "MjAyNi0wMy0xNA"},
+		{name: "bad_time", cursor: "eWVzdGVyZGF5fDBmOGU4YTUyLTRhMWQtNGMzZS05YTU1LTFiMmMzZDRlNWY2MA"},
+		{name: "bad_id", cursor: "MjAyNi0wMy0xNFQwMjoyNjo1M1p8bm90LWEtdXVpZA"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, _, err := utils.DecodeCursor(tc.cursor); err == nil {
+				t.Fatalf("expected error for cursor %q", tc.cursor)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"snack-store-api/internal/entity"
+)
+
+func TestSalesDate(t *testing.T) {
+	jakarta := time.FixedZone("WIB", 7*60*60)
+
+	testCases := []struct {
+		name          string
+		transactionAt time.Time
+		location      *time.Location
+		expected      time.Time
+	}{
+		{
+			name:          "utc_midday",
+			transactionAt: time.Date(2025, 3, 10, 12, 30, 0, 0, time.UTC),
+			location:      time.UTC,
+			expected:      time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:          "utc_midnight",
+			transactionAt: time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC),
+			location:      time.UTC,
+			expected:      time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:          "local_early_morning_stays_on_local_day",
+			transactionAt: time.Date(2025, 3, 9, 23, 0, 0, 0, time.UTC),
+			location:      jakarta,
+			expected:      time.Date(2025, 3, 10, 0, 0, 0, 0, jakarta),
+		},
+		{
+			name:          "local_late_evening",
+			transactionAt: time.Date(2025, 3, 10, 16, 30, 0, 0, time.UTC),
+			location:      jakarta,
+			expected:      time.Date(2025, 3, 10, 0, 0, 0, 0, jakarta),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := entity.SalesDate(tc.transactionAt, tc.location)
+			if !got.Equal(tc.expected) {
+				t.Fatalf("expected %s, got %s", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestIsWholeDayRange(t *testing.T) {
+	jakarta := time.FixedZone("WIB", 7*60*60)
+	day := time.Date(2025, 3, 1, 0, 0, 0, 0, jakarta)
+
+	testCases := []struct {
+		name     string
+		start    time.Time
+		end      time.Time
+		expected bool
+	}{
+		{name: "single_day", start: day, end: day.AddDate(0, 0, 1), expected: true},
+		{name: "whole_month", start: day, end: day.AddDate(0, 1, 0), expected: true},
+		{name: "empty", start: day, end: day, expected: false},
+		{name: "partial_start", start: day.Add(time.Hour), end: day.AddDate(0, 0, 1), expected: false},
+		{name: "partial_end", start: day, end: day.Add(36 * time.Hour), expected: false},
+		{name: "utc_midnight", start: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), end: day.AddDate(0, 0, 2), expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := entity.IsWholeDayRange(tc.start, tc.end, jakarta)
+			if got != tc.expected {
+				t.Fatalf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
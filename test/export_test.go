@@ -0,0 +1,181 @@
+package test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"snack-store-api/internal/export"
+
+	"github.com/xuri/excelize/v2"
+)
+
+var exportColumns = []export.Column{
+	{Title: "Customer"},
+	{Title: "Total", Kind: export.KindInt},
+	{Title: "At", Kind: export.KindDateTime},
+}
+
+func TestNegotiateFormat(t *testing.T) {
+	testCases := []struct {
+		name     string
+		format   string
+		accept   string
+		expected string
+	}{
+		{name: "explicit", format: "XLSX", accept: "text/csv", expected: export.FormatXLSX},
+		{name: "accept_csv", accept: "text/csv;q=0.9, application/json", expected: export.FormatCSV},
+		{name: "accept_xlsx", accept: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", expected: export.FormatXLSX},
+		{name: "json", accept: "application/json, */*", expected: ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := export.NegotiateFormat(tc.format, tc.accept)
+			if got != tc.expected {
+				t.Fatalf("expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestCSVWriterLocale(t *testing.T) {
+	jakarta := time.FixedZone("WIB", 7*3600)
+	at := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	testCases := []struct {
+		name     string
+		locale   string
+		expected string
+	}{
+		{name: "id", locale: export.LocaleID, expected: "\xef\xbb\xbfCustomer;Total;At\nBudi;1.234.567;2026-01-02 10:04:05\n"},
+		{name: "en", locale: export.LocaleEN, expected: "\xef\xbb\xbfCustomer,Total,At\nBudi,1234567,2026-01-02 10:04:05\n"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			writer := export.NewCSVWriter(&buf, tc.locale, jakarta)
+			if err := writer.Sheet("Transactions", exportColumns); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err := writer.Row("Budi", 1234567, at); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err := writer.Close(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if buf.String() != tc.expected {
+				t.Fatalf("expected %q, got %q", tc.expected, buf.String())
+			}
+			if err := writer.Sheet("Summary", exportColumns); err != export.ErrSingleSheet {
+				t.Fatalf("expected ErrSingleSheet, got %v", err)
+			}
+		})
+	}
+}
+
+func TestXLSXWriterSheets(t *testing.T) {
+	var buf bytes.Buffer
+	writer, err := export.NewXLSXWriter(&buf, time.UTC)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, sheet := range []string{"Summary", "Transactions"} {
+		if err := writer.Sheet(sheet, exportColumns); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := writer.Row("Budi", int64(1500), time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	written := buf.Len()
+	if err := writer.Abort(); err != nil || buf.Len() != written {
+		t.Fatalf("expected Abort after Close to do nothing, got err=%v and %d extra bytes", err, buf.Len()-written)
+	}
+
+	file, err := excelize.OpenReader(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer file.Close()
+
+	if got := strings.Join(file.GetSheetList(), ","); got != "Summary,Transactions" {
+		t.Fatalf("unexpected sheets %s", got)
+	}
+
+	rows, err := file.GetRows("Transactions", excelize.Options{RawCellValue: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rows) != 2 || rows[0][0] != "Customer" || rows[1][0] != "Budi" || rows[1][1] != "1500" {
+		t.Fatalf("unexpected rows %v", rows)
+	}
+}
+
+func TestXLSXWriterAbortWritesNothing(t *testing.T) {
+	var buf bytes.Buffer
+	writer, err := export.NewXLSXWriter(&buf, time.UTC)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := writer.Sheet("Transactions", exportColumns); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := writer.Row("Budi", int64(1500), time.Now()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := writer.Abort(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Fatalf("expected an aborted workbook to write nothing, got %d bytes", buf.Len())
+	}
+}
+
+func TestCSVWriterEscapesFormulas(t *testing.T) {
+	formula := "=1+1"
+	testCases := []struct {
+		name     string
+		value    interface{}
+		expected string
+	}{
+		{name: "equals", value: "=HYPERLINK(\"http://x\")", expected: "\"'=HYPERLINK(\"\"http://x\"\")\""},
+		{name: "plus", value: "+62812", expected: "'+62812"},
+		{name: "minus", value: "-1+1", expected: "'-1+1"},
+		{name: "at", value: "@SUM(A1)", expected: "'@SUM(A1)"},
+		{name: "tab", value: "\tcmd", expected: "'\tcmd"},
+		{name: "carriage_return", value: "\rcmd", expected: "\"'\rcmd\""},
+		{name: "pointer", value: &formula, expected: "'=1+1"},
+		{name: "plain", value: "Budi", expected: "Budi"},
+		{name: "inner_sign", value: "Budi=1", expected: "Budi=1"},
+	}
+
+	columns := []export.Column{{Title: "Name", Kind: export.KindText}, {Title: "Total", Kind: export.KindInt}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			writer := export.NewCSVWriter(&buf, export.LocaleEN, time.UTC)
+			if err := writer.Sheet("Customers", columns); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err := writer.Row(tc.value, -5); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err := writer.Close(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			expected := "\xef\xbb\xbfName,Total\n" + tc.expected + ",-5\n"
+			if buf.String() != expected {
+				t.Fatalf("expected %q, got %q", expected, buf.String())
+			}
+		})
+	}
+}
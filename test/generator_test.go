@@ -0,0 +1,189 @@
+package test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"snack-store-api/internal/entity"
+	"snack-store-api/internal/generator"
+)
+
+type memorySink struct {
+	customers    []entity.Customer
+	products     []entity.Product
+	transactions []entity.Transaction
+	redemptions  []entity.Redemption
+	sequences    []entity.ReceiptSequence
+	summary      *generator.Summary
+}
+
+func (s *memorySink) Customers(rows []entity.Customer) error {
+	s.customers = append(s.customers, rows...)
+	return nil
+}
+
+func (s *memorySink) Products(rows []entity.Product) error {
+	s.products = append(s.products, rows...)
+	return nil
+}
+
+func (s *memorySink) Transactions(rows []entity.Transaction) error {
+	s.transactions = append(s.transactions, rows...)
+	return nil
+}
+
+func (s *memorySink) Redemptions(rows []entity.Redemption) error {
+	s.redemptions = append(s.redemptions, rows...)
+	return nil
+}
+
+func (s *memorySink) ReceiptSequences(rows []entity.ReceiptSequence) error {
+	s.sequences = append(s.sequences, rows...)
+	return nil
+}
+
+func generate(t *testing.T, options generator.Options) *memorySink {
+	t.Helper()
+
+	policy := &entity.TaxPolicy{DefaultRateBps: 1100, PriceInclusive: true}
+	sink := &memorySink{}
+	summary, err := generator.Generate(options, policy, time.UTC, sink)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if summary.Transactions != options.Transactions || len(sink.transactions) != options.Transactions {
+		t.Fatalf("expected %d transactions, got %d/%d", options.Transactions, summary.Transactions, len(sink.transactions))
+	}
+	sink.summary = summary
+	return sink
+}
+
+func TestGenerateConsistency(t *testing.T) {
+	options := generator.Options{
+		Customers:    50,
+		Products:     30,
+		Transactions: 3000,
+		Start:        time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:          time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC),
+		Seed:         7,
+		BatchSize:    256,
+	}
+	sink := generate(t, options)
+
+	combos := map[string]bool{}
+	for _, product := range sink.products {
+		combos[product.Flavor+"/"+product.Size] = true
+		if product.StockQty < 0 {
+			t.Fatalf("negative stock for %s", product.ID)
+		}
+		if expected := product.Type + " " + product.Flavor + " " + product.Size; product.Name != expected {
+			t.Fatalf("expected product name %q, got %q", expected, product.Name)
+		}
+	}
+	if len(combos) != len(entity.Flavors)*len(entity.Sizes) {
+		t.Fatalf("expected every flavor and size, got %d combinations", len(combos))
+	}
+
+	moved := map[string]int{}
+	for _, transaction := range sink.transactions {
+		moved[transaction.ProductID.String()] += transaction.Qty
+	}
+	for _, redemption := range sink.redemptions {
+		moved[redemption.ProductID.String()] += redemption.Qty
+	}
+	for _, product := range sink.products {
+		opening, ok := sink.summary.OpeningStock[product.ID]
+		if !ok {
+			t.Fatalf("no opening stock for %s", product.ID)
+		}
+		if opening != product.StockQty+moved[product.ID.String()] {
+			t.Fatalf("product %s opened with %d, closed with %d after moving %d", product.ID, opening, product.StockQty, moved[product.ID.String()])
+		}
+	}
+
+	points := map[string]int{}
+	receipts := map[string]bool{}
+	end := options.End.AddDate(0, 0, 1)
+	for _, transaction := range sink.transactions {
+		if transaction.TransactionAt.Before(options.Start) || !transaction.TransactionAt.Before(end) {
+			t.Fatalf("transaction at %s outside range", transaction.TransactionAt)
+		}
+		if receipts[*transaction.ReceiptNo] {
+			t.Fatalf("duplicate receipt %s", *transaction.ReceiptNo)
+		}
+		receipts[*transaction.ReceiptNo] = true
+
+		if transaction.PointsEarned != entity.PointsEarned(transaction.TotalPrice) {
+			t.Fatalf("points earned %d for total %d", transaction.PointsEarned, transaction.TotalPrice)
+		}
+		points[transaction.CustomerID.String()] += transaction.PointsEarned
+	}
+	for _, redemption := range sink.redemptions {
+		points[redemption.CustomerID.String()] -= redemption.PointsSpent
+	}
+
+	for _, customer := range sink.customers {
+		if customer.Points != points[customer.ID.String()] {
+			t.Fatalf("customer %s has %d points, ledger %d", customer.ID, customer.Points, points[customer.ID.String()])
+		}
+		if customer.Points < 0 {
+			t.Fatalf("customer %s has negative points", customer.ID)
+		}
+	}
+}
+
+func TestGenerateDeterministic(t *testing.T) {
+	options := generator.Options{
+		Customers:    20,
+		Products:     25,
+		Transactions: 500,
+		Start:        time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+		End:          time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC),
+		Seed:         42,
+		BatchSize:    100,
+	}
+
+	first := generate(t, options)
+	second := generate(t, options)
+	if !reflect.DeepEqual(first, second) {
+		t.Fatal("expected identical datasets for the same seed")
+	}
+
+	options.Seed = 43
+	third := generate(t, options)
+	if reflect.DeepEqual(first.transactions, third.transactions) {
+		t.Fatal("expected a different dataset for another seed")
+	}
+}
+
+func TestDayWeight(t *testing.T) {
+	testCases := []struct {
+		name     string
+		day      time.Time
+		other    time.Time
+		expected bool
+	}{
+		{
+			name:     "saturday_above_tuesday",
+			day:      time.Date(2025, 3, 8, 0, 0, 0, 0, time.UTC),
+			other:    time.Date(2025, 3, 11, 0, 0, 0, 0, time.UTC),
+			expected: true,
+		},
+		{
+			name:     "payday_above_mid_month",
+			day:      time.Date(2025, 3, 26, 0, 0, 0, 0, time.UTC),
+			other:    time.Date(2025, 3, 12, 0, 0, 0, 0, time.UTC),
+			expected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := generator.DayWeight(tc.day, 0) > generator.DayWeight(tc.other, 0)
+			if result != tc.expected {
+				t.Fatalf("expected %v, got %v", tc.expected, result)
+			}
+		})
+	}
+}
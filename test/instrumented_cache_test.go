@@ -0,0 +1,57 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"snack-store-api/internal/cache"
+)
+
+func TestCacheNamespace(t *testing.T) {
+	testCases := []struct {
+		key      string
+		expected string
+	}{
+		{key: "products:date:2025-01-01", expected: "products:date"},
+		{key: "report:transactions:2025-01-01:2025-01-31:v3", expected: "report:transactions"},
+		{key: "report:version:2025-03", expected: "report:version"},
+		{key: "report:transactions:", expected: "report:transactions"},
+		{key: "session:abc", expected: "session"},
+		{key: "plain", expected: "plain"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.key, func(t *testing.T) {
+			got := cache.Namespace(tc.key)
+			if got != tc.expected {
+				t.Fatalf("expected %s, got %s", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestInstrumentedCacheStats(t *testing.T) {
+	ctx := context.Background()
+	store := cache.NewInstrumentedCache(cache.NewMemoryCache(10))
+
+	_ = store.Set(ctx, "products:date:2025-01-01", "[]", 0)
+	_, _, _ = store.Get(ctx, "products:date:2025-01-01")
+	_, _, _ = store.Get(ctx, "products:date:2025-01-02")
+	_, _ = store.Incr(ctx, "report:version:2025-01-01", 0)
+	_, _ = store.MGet(ctx, "report:version:2025-01-01", "report:version:2025-01-02")
+
+	stats := store.Stats()
+	if len(stats) != 2 {
+		t.Fatalf("expected 2 namespaces, got %d", len(stats))
+	}
+
+	products := stats[0]
+	if products.Namespace != "products:date" || products.Hits != 1 || products.Misses != 1 || products.Operations != 3 {
+		t.Fatalf("unexpected products stats: %+v", products)
+	}
+
+	versions := stats[1]
+	if versions.Namespace != "report:version" || versions.Hits != 1 || versions.Misses != 1 || versions.Operations != 2 {
+		t.Fatalf("unexpected version stats: %+v", versions)
+	}
+}
@@ -0,0 +1,115 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"snack-store-api/internal/cache"
+)
+
+func TestMemoryCacheGetSet(t *testing.T) {
+	ctx := context.Background()
+	store := cache.NewMemoryCache(10)
+
+	if _, ok, _ := store.Get(ctx, "missing"); ok {
+		t.Fatalf("expected miss for unknown key")
+	}
+
+	_ = store.Set(ctx, "key", "first", 0)
+	_ = store.Set(ctx, "key", "second", 0)
+
+	value, ok, err := store.Get(ctx, "key")
+	if err != nil || !ok || value != "second" {
+		t.Fatalf("expected second, got %q ok=%v err=%v", value, ok, err)
+	}
+}
+
+func TestMemoryCacheTTL(t *testing.T) {
+	ctx := context.Background()
+	store := cache.NewMemoryCache(10)
+
+	_ = store.Set(ctx, "short", "value", 10*time.Millisecond)
+	_ = store.Set(ctx, "forever", "value", 0)
+	time.Sleep(30 * time.Millisecond)
+
+	if _, ok, _ := store.Get(ctx, "short"); ok {
+		t.Fatalf("expected expired key to miss")
+	}
+	if _, ok, _ := store.Get(ctx, "forever"); !ok {
+		t.Fatalf("expected key without ttl to stay")
+	}
+}
+
+func TestMemoryCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	store := cache.NewMemoryCache(2)
+
+	_ = store.Set(ctx, "a", "1", 0)
+	_ = store.Set(ctx, "b", "2", 0)
+	_, _, _ = store.Get(ctx, "a")
+	_ = store.Set(ctx, "c", "3", 0)
+
+	if _, ok, _ := store.Get(ctx, "b"); ok {
+		t.Fatalf("expected b to be evicted")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, ok, _ := store.Get(ctx, key); !ok {
+			t.Fatalf("expected %s to stay", key)
+		}
+	}
+	if store.Len() != 2 {
+		t.Fatalf("expected 2 entries, got %d", store.Len())
+	}
+}
+
+func TestMemoryCacheDelByPrefix(t *testing.T) {
+	ctx := context.Background()
+	store := cache.NewMemoryCache(10)
+
+	_ = store.Set(ctx, "report:transactions:a", "1", 0)
+	_ = store.Set(ctx, "report:transactions:b", "2", 0)
+	_ = store.Set(ctx, "products:date:2025-01-01", "3", 0)
+
+	_ = store.DelByPrefix(ctx, "report:transactions:")
+
+	if store.Len() != 1 {
+		t.Fatalf("expected 1 entry, got %d", store.Len())
+	}
+	if _, ok, _ := store.Get(ctx, "products:date:2025-01-01"); !ok {
+		t.Fatalf("expected unrelated key to stay")
+	}
+
+	_ = store.Del(ctx, "products:date:2025-01-01")
+	if store.Len() != 0 {
+		t.Fatalf("expected empty cache, got %d", store.Len())
+	}
+}
+
+func TestMemoryCacheIncrAndMGet(t *testing.T) {
+	ctx := context.Background()
+	store := cache.NewMemoryCache(10)
+
+	for i := int64(1); i <= 3; i++ {
+		got, err := store.Incr(ctx, "counter", 0)
+		if err != nil || got != i {
+			t.Fatalf("expected %d, got %d err=%v", i, got, err)
+		}
+	}
+
+	_ = store.Set(ctx, "text", "abc", 0)
+	if _, err := store.Incr(ctx, "text", 0); err == nil {
+		t.Fatalf("expected error incrementing non-integer value")
+	}
+
+	values, err := store.MGet(ctx, "counter", "missing", "text")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []string{"3", "", "abc"}
+	for i := range expected {
+		if values[i] != expected[i] {
+			t.Fatalf("expected %v, got %v", expected, values)
+		}
+	}
+}
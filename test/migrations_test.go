@@ -0,0 +1,200 @@
+package test
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"snack-store-api/internal/migrations"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestParseMigrations(t *testing.T) {
+	file := func(content string) *fstest.MapFile {
+		return &fstest.MapFile{Data: []byte(content)}
+	}
+
+	testCases := []struct {
+		name     string
+		files    fstest.MapFS
+		expected []int64
+		wantErr  bool
+	}{
+		{
+			name: "sorted_by_version",
+			files: fstest.MapFS{
+				"0010_add_index.up.sql":   file("up10"),
+				"0010_add_index.down.sql": file("down10"),
+				"0002_second.up.sql":      file("up2"),
+				"0002_second.down.sql":    file("down2"),
+			},
+			expected: []int64{2, 10},
+		},
+		{
+			name: "missing_down",
+			files: fstest.MapFS{
+				"0001_init.up.sql": file("up"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "conflicting_names",
+			files: fstest.MapFS{
+				"0001_init.up.sql":    file("up"),
+				"0001_other.down.sql": file("down"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid_file_name",
+			files: fstest.MapFS{
+				"init.sql": file("up"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "zero_version",
+			files: fstest.MapFS{
+				"0000_init.up.sql":   file("up"),
+				"0000_init.down.sql": file("down"),
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := migrations.ParseMigrations(tc.files)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %+v", result)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(result) != len(tc.expected) {
+				t.Fatalf("expected %d migrations, got %d", len(tc.expected), len(result))
+			}
+			for i, version := range tc.expected {
+				if result[i].Version != version {
+					t.Fatalf("expected version %d at %d, got %d", version, i, result[i].Version)
+				}
+			}
+		})
+	}
+}
+
+func TestEmbeddedMigrations(t *testing.T) {
+	result, err := migrations.Embedded()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for i, migration := range result {
+		if migration.Version != int64(i+1) {
+			t.Fatalf("expected contiguous versions, got %d at position %d", migration.Version, i)
+		}
+	}
+}
+
+func TestEmbeddedMigrationsAddColumnsIdempotently(t *testing.T) {
+	result, err := migrations.Embedded()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	addColumn := regexp.MustCompile(`(?i)ADD\s+COLUMN\s+(IF\s+NOT\s+EXISTS\s+)?`)
+	for _, migration := range result[1:] {
+		for _, match := range addColumn.FindAllStringSubmatch(migration.Up, -1) {
+			if match[1] == "" {
+				t.Fatalf("migration %d_%s adds a column without IF NOT EXISTS", migration.Version, migration.Name)
+			}
+		}
+	}
+}
+
+// TestMigrationsUpgradeBaseline applies every migration to a database created
+// from the baseline schema before migrations were tracked. It needs a
+// PostgreSQL DSN in TEST_DATABASE_DSN and works in a throwaway schema.
+func TestMigrationsUpgradeBaseline(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_DSN")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_DSN is not set")
+	}
+
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+
+	admin, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	schema := fmt.Sprintf("migrations_test_%d", time.Now().UnixNano())
+	if err := admin.Exec("CREATE SCHEMA " + schema).Error; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	t.Cleanup(func() {
+		admin.Exec("DROP SCHEMA " + schema + " CASCADE")
+	})
+
+	db, err := gorm.Open(postgres.Open(dsn+" search_path="+schema+",public"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	all, err := migrations.Embedded()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := db.Exec(all[0].Up).Error; err != nil {
+		t.Fatalf("failed to create the baseline schema: %v", err)
+	}
+	err = db.Exec(`
+INSERT INTO customers (id, name, points) VALUES ('00000000-0000-0000-0000-000000000001', 'Budi', 25);
+INSERT INTO products (id, name, type, flavor, size, price, stock_qty, manufactured_date)
+VALUES ('00000000-0000-0000-0000-000000000002', 'Keripik Original Small', 'Keripik Pangsit', 'Original', 'Small', 10000, 5, '2025-01-01');
+INSERT INTO transactions (customer_id, product_id, qty, unit_price, total_price, points_earned, transaction_at)
+VALUES ('00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000002', 1, 10000, 10000, 10, now());
+`).Error
+	if err != nil {
+		t.Fatalf("failed to insert baseline rows: %v", err)
+	}
+
+	migrator, err := migrations.NewMigrator(db, log)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	applied, err := migrator.Up()
+	if err != nil {
+		t.Fatalf("migrate up failed on the baseline schema: %v", err)
+	}
+	if applied != len(all) {
+		t.Fatalf("expected %d migrations applied, got %d", len(all), applied)
+	}
+
+	drift, err := migrations.CheckDrift(db)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(drift) > 0 {
+		t.Fatalf("expected no drift after migrate up, got %v", drift)
+	}
+
+	var openingPoints int
+	if err := db.Raw("SELECT opening_points FROM customers").Scan(&openingPoints).Error; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if openingPoints != 15 {
+		t.Fatalf("expected opening points 15 backfilled from the ledger, got %d", openingPoints)
+	}
+}
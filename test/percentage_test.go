@@ -0,0 +1,31 @@
+package test
+
+import (
+	"testing"
+
+	"snack-store-api/internal/utils"
+)
+
+func TestPercentage(t *testing.T) {
+	testCases := []struct {
+		name     string
+		part     int64
+		total    int64
+		expected float64
+	}{
+		{name: "zero_total", part: 5, total: 0, expected: 0},
+		{name: "whole", part: 40, total: 40, expected: 100},
+		{name: "half", part: 1, total: 2, expected: 50},
+		{name: "rounded", part: 1, total: 3, expected: 33.33},
+		{name: "rounded_up", part: 2, total: 3, expected: 66.67},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := utils.Percentage(tc.part, tc.total)
+			if got != tc.expected {
+				t.Fatalf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
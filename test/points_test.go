@@ -51,3 +51,65 @@ func TestPointsCost(t *testing.T) {
 		})
 	}
 }
+
+func TestPointLiability(t *testing.T) {
+	testCases := []struct {
+		name          string
+		outstanding   int64
+		pointsSpent   int64
+		redeemedValue int64
+		expectedValue float64
+		expectedTotal int64
+		expectedOK    bool
+	}{
+		{name: "no_redemption", outstanding: 1000, pointsSpent: 0, redeemedValue: 0, expectedOK: false},
+		{name: "whole_rate", outstanding: 1000, pointsSpent: 200, redeemedValue: 2000, expectedValue: 10, expectedTotal: 10000, expectedOK: true},
+		{name: "fractional_rate", outstanding: 900, pointsSpent: 300, redeemedValue: 1000, expectedValue: 3.33, expectedTotal: 3000, expectedOK: true},
+		{name: "no_outstanding", outstanding: 0, pointsSpent: 500, redeemedValue: 7500, expectedValue: 15, expectedTotal: 0, expectedOK: true},
+		{name: "liability_uses_unrounded_rate", outstanding: 3, pointsSpent: 3, redeemedValue: 10, expectedValue: 3.33, expectedTotal: 10, expectedOK: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			value, total, ok := entity.PointLiability(tc.outstanding, tc.pointsSpent, tc.redeemedValue)
+			if ok != tc.expectedOK {
+				t.Fatalf("expected ok %v, got %v", tc.expectedOK, ok)
+			}
+			if value != tc.expectedValue || total != tc.expectedTotal {
+				t.Fatalf("expected %.2f / %d, got %.2f / %d", tc.expectedValue, tc.expectedTotal, value, total)
+			}
+		})
+	}
+}
+
+func TestReconcilePoints(t *testing.T) {
+	opening := func(points int) *int {
+		return &points
+	}
+
+	testCases := []struct {
+		name           string
+		stored         int
+		opening        *int
+		earned         int64
+		spent          int64
+		expectedPoints int64
+		expectedStatus string
+	}{
+		{name: "carried_over_balance_matches", stored: 250, opening: opening(430), earned: 20, spent: 200, expectedPoints: 250, expectedStatus: entity.PointsMatch},
+		{name: "ledger_only_matches", stored: 25, opening: opening(0), earned: 25, expectedPoints: 25, expectedStatus: entity.PointsMatch},
+		{name: "drifted_balance", stored: 10, opening: opening(0), earned: 25, expectedPoints: 25, expectedStatus: entity.PointsCorrectable},
+		{name: "no_opening_balance", stored: 0, earned: 35, expectedPoints: 35, expectedStatus: entity.PointsNoOpening},
+		{name: "no_opening_would_be_negative", stored: 250, earned: 20, spent: 200, expectedPoints: -180, expectedStatus: entity.PointsNoOpening},
+		{name: "negative_ledger", stored: 0, opening: opening(0), earned: 20, spent: 200, expectedPoints: -180, expectedStatus: entity.PointsNegative},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			points, status := entity.ReconcilePoints(tc.stored, tc.opening, tc.earned, tc.spent)
+			if points != tc.expectedPoints || status != tc.expectedStatus {
+				t.Fatalf("expected %d %s, got %d %s", tc.expectedPoints, tc.expectedStatus, points, status)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"snack-store-api/internal/entity"
+	"snack-store-api/internal/model"
+	"snack-store-api/internal/model/converter"
+	"snack-store-api/internal/receipt"
+
+	"github.com/google/uuid"
+)
+
+func TestFormatReceiptNo(t *testing.T) {
+	date := time.Date(2025, 10, 22, 0, 0, 0, 0, time.UTC)
+
+	got := entity.FormatReceiptNo(date, 7)
+	if got != "20251022-0007" {
+		t.Fatalf("expected 20251022-0007, got %s", got)
+	}
+}
+
+func TestFormatAmount(t *testing.T) {
+	testCases := []struct {
+		name     string
+		amount   int
+		expected string
+	}{
+		{name: "zero", amount: 0, expected: "0"},
+		{name: "hundreds", amount: 999, expected: "999"},
+		{name: "thousands", amount: 10000, expected: "10.000"},
+		{name: "millions", amount: 1234567, expected: "1.234.567"},
+		{name: "negative", amount: -25000, expected: "-25.000"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := receipt.FormatAmount(tc.amount)
+			if got != tc.expected {
+				t.Fatalf("expected %s, got %s", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestReceiptFallbackNumberIsShort(t *testing.T) {
+	id := uuid.MustParse("1a2b3c4d-1111-2222-3333-444455556666")
+
+	got := converter.TransactionToReceipt(&entity.Transaction{ID: id}).ReceiptNo
+	if got != "TRX-1A2B3C4D" {
+		t.Fatalf("expected TRX-1A2B3C4D, got %s", got)
+	}
+}
+
+func TestReceiptTextFitsPaperWidth(t *testing.T) {
+	balance := 270
+	renderer := receipt.NewRenderer(receipt.Store{
+		Name:    "Snack Store",
+		Address: "Jl. Jenderal Sudirman No. 123, Kebayoran Baru, Jakarta Selatan",
+		Footer:  "Thank you for shopping!",
+	}, time.UTC)
+
+	body := renderer.Text(&model.ReceiptResponse{
+		ReceiptNo:    "1a2b3c4d-1111-2222-3333-444455556666",
+		CustomerName: "Fery Ferdiansyah Pratama Wijaya Kusuma",
+		Items: []model.ReceiptItem{
+			{ProductName: "Keripik Pangsit Super Pedas Edisi Spesial", Size: "Large", Flavor: "Jagung Bakar", Qty: 2, UnitPrice: 10000, Subtotal: 20000},
+		},
+		NetAmount:     18018,
+		TaxAmount:     1982,
+		TaxRate:       11,
+		TotalPrice:    20000,
+		PointsEarned:  20,
+		PointsBalance: &balance,
+		TransactionAt: time.Date(2025, 10, 22, 15, 0, 22, 0, time.UTC),
+	})
+
+	for _, line := range strings.Split(strings.TrimRight(string(body), "\n"), "\n") {
+		if utf8.RuneCountInString(line) > receipt.LineWidth {
+			t.Fatalf("line exceeds %d characters: %q", receipt.LineWidth, line)
+		}
+	}
+
+	if !bytes.Contains(body, []byte("Rp 20.000")) {
+		t.Fatalf("expected total in receipt, got:\n%s", body)
+	}
+}
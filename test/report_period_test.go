@@ -0,0 +1,46 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"snack-store-api/internal/entity"
+)
+
+func TestComparisonPeriod(t *testing.T) {
+	date := func(value string) time.Time {
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			t.Fatalf("invalid date %s: %v", value, err)
+		}
+		return parsed
+	}
+
+	testCases := []struct {
+		name          string
+		start         string
+		end           string
+		mode          string
+		expectedStart string
+		expectedEnd   string
+	}{
+		{name: "previous_month", start: "2026-03-01", end: "2026-04-01", mode: entity.ComparePrevious, expectedStart: "2026-02-01", expectedEnd: "2026-03-01"},
+		{name: "previous_quarter", start: "2026-04-01", end: "2026-07-01", mode: entity.ComparePrevious, expectedStart: "2026-01-01", expectedEnd: "2026-04-01"},
+		{name: "previous_days", start: "2026-03-10", end: "2026-03-17", mode: entity.ComparePrevious, expectedStart: "2026-03-03", expectedEnd: "2026-03-10"},
+		{name: "yoy_month", start: "2026-02-01", end: "2026-03-01", mode: entity.CompareYoY, expectedStart: "2025-02-01", expectedEnd: "2025-03-01"},
+		{name: "yoy_days", start: "2026-03-10", end: "2026-03-17", mode: entity.CompareYoY, expectedStart: "2025-03-10", expectedEnd: "2025-03-17"},
+		{name: "yoy_leap_day", start: "2024-02-29", end: "2024-03-01", mode: entity.CompareYoY, expectedStart: "2023-02-28", expectedEnd: "2023-03-01"},
+		{name: "yoy_leap_february", start: "2024-02-01", end: "2024-03-01", mode: entity.CompareYoY, expectedStart: "2023-02-01", expectedEnd: "2023-03-01"},
+		{name: "yoy_into_leap_year", start: "2025-02-28", end: "2025-03-01", mode: entity.CompareYoY, expectedStart: "2024-02-28", expectedEnd: "2024-03-01"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gotStart, gotEnd := entity.ComparisonPeriod(date(tc.start), date(tc.end), tc.mode)
+			if !gotStart.Equal(date(tc.expectedStart)) || !gotEnd.Equal(date(tc.expectedEnd)) {
+				t.Fatalf("expected %s..%s, got %s..%s", tc.expectedStart, tc.expectedEnd,
+					gotStart.Format("2006-01-02"), gotEnd.Format("2006-01-02"))
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"snack-store-api/internal/constants"
+	"snack-store-api/internal/entity"
+)
+
+func TestSeriesBucket(t *testing.T) {
+	jakarta := time.FixedZone("WIB", 7*60*60)
+
+	testCases := []struct {
+		name        string
+		at          time.Time
+		granularity string
+		expected    time.Time
+	}{
+		{
+			name:        "day",
+			at:          time.Date(2025, 3, 12, 15, 4, 5, 0, time.UTC),
+			granularity: constants.GranularityDay,
+			expected:    time.Date(2025, 3, 12, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:        "day_keeps_location",
+			at:          time.Date(2025, 3, 12, 0, 30, 0, 0, jakarta),
+			granularity: constants.GranularityDay,
+			expected:    time.Date(2025, 3, 12, 0, 0, 0, 0, jakarta),
+		},
+		{
+			name:        "week_midweek",
+			at:          time.Date(2025, 3, 12, 9, 0, 0, 0, time.UTC),
+			granularity: constants.GranularityWeek,
+			expected:    time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:        "week_sunday",
+			at:          time.Date(2025, 3, 16, 23, 0, 0, 0, time.UTC),
+			granularity: constants.GranularityWeek,
+			expected:    time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:        "week_across_year",
+			at:          time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			granularity: constants.GranularityWeek,
+			expected:    time.Date(2024, 12, 30, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:        "month",
+			at:          time.Date(2024, 2, 29, 12, 0, 0, 0, time.UTC),
+			granularity: constants.GranularityMonth,
+			expected:    time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := entity.SeriesBucket(tc.at, tc.granularity)
+			if !got.Equal(tc.expected) || got.Location() != tc.expected.Location() {
+				t.Fatalf("expected %s, got %s", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestSeriesBuckets(t *testing.T) {
+	jakarta := time.FixedZone("WIB", 7*60*60)
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, jakarta)
+	}
+
+	testCases := []struct {
+		name        string
+		start       time.Time
+		end         time.Time
+		granularity string
+		expected    []string
+	}{
+		{
+			name:        "days",
+			start:       date(2025, 2, 27),
+			end:         date(2025, 3, 3),
+			granularity: constants.GranularityDay,
+			expected:    []string{"2025-02-27", "2025-02-28", "2025-03-01", "2025-03-02"},
+		},
+		{
+			name:        "partial_weeks",
+			start:       date(2025, 3, 12),
+			end:         date(2025, 3, 25),
+			granularity: constants.GranularityWeek,
+			expected:    []string{"2025-03-10", "2025-03-17", "2025-03-24"},
+		},
+		{
+			name:        "partial_months",
+			start:       date(2025, 1, 31),
+			end:         date(2025, 3, 2),
+			granularity: constants.GranularityMonth,
+			expected:    []string{"2025-01-01", "2025-02-01", "2025-03-01"},
+		},
+		{
+			name:        "empty_range",
+			start:       date(2025, 3, 1),
+			end:         date(2025, 3, 1),
+			granularity: constants.GranularityDay,
+			expected:    nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := entity.SeriesBuckets(tc.start, tc.end, tc.granularity)
+			if len(got) != len(tc.expected) {
+				t.Fatalf("expected %d buckets, got %d: %v", len(tc.expected), len(got), got)
+			}
+			for i, bucket := range got {
+				if bucket.Format(constants.DateLayout) != tc.expected[i] {
+					t.Fatalf("bucket %d: expected %s, got %s", i, tc.expected[i], bucket.Format(constants.DateLayout))
+				}
+			}
+		})
+	}
+}
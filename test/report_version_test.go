@@ -0,0 +1,142 @@
+package test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"snack-store-api/internal/cache"
+	"snack-store-api/internal/entity"
+)
+
+func TestReportVersionBuckets(t *testing.T) {
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	testCases := []struct {
+		name     string
+		start    time.Time
+		end      time.Time
+		expected []string
+	}{
+		{
+			name:     "single_day",
+			start:    date(2025, 3, 10),
+			end:      date(2025, 3, 11),
+			expected: []string{"2025-03-10"},
+		},
+		{
+			name:     "whole_month",
+			start:    date(2025, 3, 1),
+			end:      date(2025, 4, 1),
+			expected: []string{"2025-03"},
+		},
+		{
+			name:     "partial_months_around_whole_month",
+			start:    date(2025, 1, 30),
+			end:      date(2025, 3, 3),
+			expected: []string{"2025-01-30", "2025-01-31", "2025-02", "2025-03-01", "2025-03-02"},
+		},
+		{
+			name:     "month_start_but_not_whole",
+			start:    date(2025, 2, 1),
+			end:      date(2025, 2, 3),
+			expected: []string{"2025-02-01", "2025-02-02"},
+		},
+		{
+			name:     "whole_years",
+			start:    date(2023, 12, 30),
+			end:      date(2025, 2, 2),
+			expected: []string{"2023-12-30", "2023-12-31", "2024", "2025-01", "2025-02-01"},
+		},
+		{
+			name:     "too_many_buckets",
+			start:    date(2023, 1, 2),
+			end:      date(2025, 3, 30),
+			expected: []string{"all"},
+		},
+		{
+			name:     "empty",
+			start:    date(2025, 2, 1),
+			end:      date(2025, 2, 1),
+			expected: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := entity.ReportVersionBuckets(tc.start, tc.end)
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Fatalf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestSaleVersionBuckets(t *testing.T) {
+	jakarta := time.FixedZone("WIB", 7*60*60)
+	transactionAt := time.Date(2025, 3, 31, 22, 0, 0, 0, time.UTC)
+
+	got := entity.SaleVersionBuckets(transactionAt, jakarta)
+	expected := []string{"2025-04-01", "2025-04", "2025", "all"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestVersion(t *testing.T) {
+	ctx := context.Background()
+
+	version := func(t *testing.T, store cache.Cache, keys ...string) string {
+		t.Helper()
+		value, err := cache.Version(ctx, store, keys...)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return value
+	}
+
+	t.Run("stable_until_retired", func(t *testing.T) {
+		store := cache.NewMemoryCache(0)
+		first := version(t, store, "v:a", "v:b")
+		if again := version(t, store, "v:a", "v:b"); again != first {
+			t.Fatalf("expected %s, got %s", first, again)
+		}
+
+		if err := cache.RetireVersion(ctx, store, "v:c"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if unrelated := version(t, store, "v:a", "v:b"); unrelated != first {
+			t.Fatalf("retiring another key changed the version from %s to %s", first, unrelated)
+		}
+
+		if err := cache.RetireVersion(ctx, store, "v:b"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if retired := version(t, store, "v:a", "v:b"); retired == first {
+			t.Fatalf("expected a new version after retiring, got %s again", retired)
+		}
+	})
+
+	t.Run("eviction_never_restores_an_old_version", func(t *testing.T) {
+		store := cache.NewMemoryCache(2)
+		seen := map[string]bool{version(t, store, "v:a", "v:b"): true}
+
+		for i := 0; i < 5; i++ {
+			if err := store.Set(ctx, "other:1", "x", 0); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err := store.Set(ctx, "other:2", "x", 0); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			current := version(t, store, "v:a", "v:b")
+			if seen[current] {
+				t.Fatalf("version %s was issued before eviction", current)
+			}
+			seen[current] = true
+		}
+	})
+}
@@ -0,0 +1,127 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"snack-store-api/internal/entity"
+)
+
+func TestRFMQuintile(t *testing.T) {
+	testCases := []struct {
+		name      string
+		atOrBelow int
+		total     int
+		expected  int
+	}{
+		{name: "single_customer", atOrBelow: 1, total: 1, expected: 5},
+		{name: "all_tied", atOrBelow: 7, total: 7, expected: 5},
+		{name: "lowest_of_five", atOrBelow: 1, total: 5, expected: 1},
+		{name: "middle_of_five", atOrBelow: 3, total: 5, expected: 3},
+		{name: "lowest_of_many", atOrBelow: 1, total: 100, expected: 1},
+		{name: "just_above_first_fifth", atOrBelow: 21, total: 100, expected: 2},
+		{name: "lowest_of_two", atOrBelow: 1, total: 2, expected: 3},
+		{name: "empty_population", atOrBelow: 0, total: 0, expected: 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := entity.RFMQuintile(tc.atOrBelow, tc.total)
+			if got != tc.expected {
+				t.Fatalf("expected %d, got %d", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestRFMSegment(t *testing.T) {
+	asOf := time.Date(2025, 6, 30, 12, 0, 0, 0, time.UTC)
+	old := asOf.AddDate(0, -6, 0)
+	recent := asOf.AddDate(0, 0, -entity.RFMNewCustomerDays)
+
+	testCases := []struct {
+		name            string
+		recency         int
+		frequency       int
+		monetary        int
+		firstPurchaseAt time.Time
+		expected        string
+	}{
+		{name: "champions", recency: 4, frequency: 4, monetary: 1, firstPurchaseAt: old, expected: entity.SegmentChampions},
+		{name: "champions_before_new", recency: 5, frequency: 5, monetary: 5, firstPurchaseAt: recent, expected: entity.SegmentChampions},
+		{name: "new_at_boundary", recency: 5, frequency: 1, monetary: 1, firstPurchaseAt: recent, expected: entity.SegmentNew},
+		{name: "at_risk_by_frequency", recency: 2, frequency: 3, monetary: 1, firstPurchaseAt: old, expected: entity.SegmentAtRisk},
+		{name: "at_risk_by_monetary", recency: 1, frequency: 1, monetary: 3, firstPurchaseAt: old, expected: entity.SegmentAtRisk},
+		{name: "lost", recency: 1, frequency: 2, monetary: 2, firstPurchaseAt: old, expected: entity.SegmentLost},
+		{name: "regular", recency: 3, frequency: 2, monetary: 5, firstPurchaseAt: old, expected: entity.SegmentRegular},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := entity.RFMSegment(tc.recency, tc.frequency, tc.monetary, tc.firstPurchaseAt, asOf)
+			if got != tc.expected {
+				t.Fatalf("expected %s, got %s", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestScoreRFM(t *testing.T) {
+	asOf := time.Date(2025, 6, 30, 12, 0, 0, 0, time.UTC)
+	first := asOf.AddDate(0, -6, 0)
+
+	t.Run("single_customer_is_champion", func(t *testing.T) {
+		scores := entity.ScoreRFM([]entity.RFMStats{
+			{FirstPurchaseAt: first, LastPurchaseAt: asOf.Add(-36 * time.Hour), Frequency: 3, Monetary: 50000},
+		}, asOf)
+
+		expected := entity.RFMScore{RecencyDays: 1, RecencyScore: 5, FrequencyScore: 5, MonetaryScore: 5, Segment: entity.SegmentChampions}
+		if scores[0] != expected {
+			t.Fatalf("expected %+v, got %+v", expected, scores[0])
+		}
+	})
+
+	t.Run("ties_share_a_score", func(t *testing.T) {
+		last := asOf.AddDate(0, 0, -10)
+		scores := entity.ScoreRFM([]entity.RFMStats{
+			{FirstPurchaseAt: first, LastPurchaseAt: last, Frequency: 2, Monetary: 1000},
+			{FirstPurchaseAt: first, LastPurchaseAt: last, Frequency: 2, Monetary: 1000},
+			{FirstPurchaseAt: first, LastPurchaseAt: asOf, Frequency: 9, Monetary: 9000},
+		}, asOf)
+
+		if scores[0] != scores[1] {
+			t.Fatalf("expected tied customers to share scores, got %+v and %+v", scores[0], scores[1])
+		}
+		if scores[0].RecencyScore != 4 || scores[0].FrequencyScore != 4 || scores[0].MonetaryScore != 4 {
+			t.Fatalf("expected tied scores of 4, got %+v", scores[0])
+		}
+		if scores[2].RecencyScore != 5 || scores[2].FrequencyScore != 5 || scores[2].MonetaryScore != 5 {
+			t.Fatalf("expected top scores of 5, got %+v", scores[2])
+		}
+	})
+
+	t.Run("spread_population", func(t *testing.T) {
+		stats := make([]entity.RFMStats, 0, 5)
+		for i := 0; i < 5; i++ {
+			stats = append(stats, entity.RFMStats{
+				FirstPurchaseAt: first,
+				LastPurchaseAt:  asOf.AddDate(0, 0, -10*(5-i)),
+				Frequency:       i + 1,
+				Monetary:        int64(5-i) * 1000,
+			})
+		}
+
+		scores := entity.ScoreRFM(stats, asOf)
+		for i, score := range scores {
+			if score.RecencyScore != i+1 || score.FrequencyScore != i+1 || score.MonetaryScore != 5-i {
+				t.Fatalf("customer %d: unexpected scores %+v", i, score)
+			}
+			if score.RecencyDays != 10*(5-i) {
+				t.Fatalf("customer %d: expected recency %d days, got %d", i, 10*(5-i), score.RecencyDays)
+			}
+		}
+		if scores[0].Segment != entity.SegmentAtRisk || scores[4].Segment != entity.SegmentChampions {
+			t.Fatalf("unexpected segments %s and %s", scores[0].Segment, scores[4].Segment)
+		}
+	})
+}
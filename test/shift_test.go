@@ -0,0 +1,47 @@
+package test
+
+import (
+	"testing"
+
+	"snack-store-api/internal/entity"
+)
+
+func TestReconcileShift(t *testing.T) {
+	counted := func(amount int) *int {
+		return &amount
+	}
+
+	testCases := []struct {
+		name               string
+		openingFloat       int
+		totalSales         int64
+		countedCash        *int
+		expectedCash       int64
+		expectedDifference *int64
+	}{
+		{name: "open_shift_without_count", openingFloat: 200000, totalSales: 245000, countedCash: nil, expectedCash: 445000},
+		{name: "no_sales", openingFloat: 100000, totalSales: 0, countedCash: counted(100000), expectedCash: 100000, expectedDifference: ptrInt64(0)},
+		{name: "balanced", openingFloat: 200000, totalSales: 245000, countedCash: counted(445000), expectedCash: 445000, expectedDifference: ptrInt64(0)},
+		{name: "short", openingFloat: 200000, totalSales: 245000, countedCash: counted(444500), expectedCash: 445000, expectedDifference: ptrInt64(-500)},
+		{name: "over", openingFloat: 0, totalSales: 15000, countedCash: counted(20000), expectedCash: 15000, expectedDifference: ptrInt64(5000)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			expected, difference := entity.ReconcileShift(tc.openingFloat, tc.totalSales, tc.countedCash)
+			if expected != tc.expectedCash {
+				t.Fatalf("expected cash %d, got %d", tc.expectedCash, expected)
+			}
+			if (difference == nil) != (tc.expectedDifference == nil) {
+				t.Fatalf("expected difference %v, got %v", tc.expectedDifference, difference)
+			}
+			if difference != nil && *difference != *tc.expectedDifference {
+				t.Fatalf("expected difference %d, got %d", *tc.expectedDifference, *difference)
+			}
+		})
+	}
+}
+
+func ptrInt64(value int64) *int64 {
+	return &value
+}
@@ -0,0 +1,32 @@
+package test
+
+import (
+	"testing"
+
+	"snack-store-api/internal/entity"
+)
+
+func TestStockAgeBucket(t *testing.T) {
+	testCases := []struct {
+		name     string
+		ageDays  int
+		expected string
+	}{
+		{name: "future_date", ageDays: -2, expected: entity.StockAge0To30},
+		{name: "fresh", ageDays: 0, expected: entity.StockAge0To30},
+		{name: "upper_0_30", ageDays: 30, expected: entity.StockAge0To30},
+		{name: "lower_31_60", ageDays: 31, expected: entity.StockAge31To60},
+		{name: "upper_61_90", ageDays: 90, expected: entity.StockAge61To90},
+		{name: "upper_91_180", ageDays: 180, expected: entity.StockAge91To180},
+		{name: "old", ageDays: 181, expected: entity.StockAgeOver180},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := entity.StockAgeBucket(tc.ageDays)
+			if got != tc.expected {
+				t.Fatalf("expected %s, got %s", tc.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package test
+
+import (
+	"testing"
+
+	"snack-store-api/internal/entity"
+)
+
+func TestCalculateTax(t *testing.T) {
+	testCases := []struct {
+		name      string
+		amount    int
+		rateBps   int
+		inclusive bool
+		expected  entity.TaxAmount
+	}{
+		{name: "zero_rate", amount: 10000, rateBps: 0, inclusive: true, expected: entity.TaxAmount{Net: 10000, Tax: 0, Gross: 10000}},
+		{name: "zero_amount", amount: 0, rateBps: 1100, inclusive: false, expected: entity.TaxAmount{Net: 0, Tax: 0, Gross: 0}},
+		{name: "exclusive", amount: 10000, rateBps: 1100, inclusive: false, expected: entity.TaxAmount{Net: 10000, Tax: 1100, Gross: 11100}},
+		{name: "exclusive_rounding", amount: 12345, rateBps: 1100, inclusive: false, expected: entity.TaxAmount{Net: 12345, Tax: 1358, Gross: 13703}},
+		{name: "inclusive", amount: 11100, rateBps: 1100, inclusive: true, expected: entity.TaxAmount{Net: 10000, Tax: 1100, Gross: 11100}},
+		{name: "inclusive_rounding", amount: 20000, rateBps: 1100, inclusive: true, expected: entity.TaxAmount{Net: 18018, Tax: 1982, Gross: 20000}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := entity.CalculateTax(tc.amount, tc.rateBps, tc.inclusive)
+			if got != tc.expected {
+				t.Fatalf("expected %+v, got %+v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestTaxPolicyRateFor(t *testing.T) {
+	policy := &entity.TaxPolicy{
+		DefaultRateBps: 1100,
+		RatesBps:       map[string]int{"minuman": 0},
+	}
+
+	testCases := []struct {
+		name        string
+		productType string
+		expected    int
+	}{
+		{name: "default", productType: "Keripik Pangsit", expected: 1100},
+		{name: "override", productType: "Minuman", expected: 0},
+		{name: "override_case_insensitive", productType: " MINUMAN ", expected: 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := policy.RateFor(tc.productType)
+			if got != tc.expected {
+				t.Fatalf("expected %d, got %d", tc.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"snack-store-api/internal/cache"
+
+	"github.com/sirupsen/logrus"
+)
+
+func newTestTieredCache(l1TTL time.Duration) (*cache.TieredCache, *cache.MemoryCache, *cache.MemoryCache) {
+	l1 := cache.NewMemoryCache(10)
+	l2 := cache.NewMemoryCache(10)
+	return cache.NewTieredCache(l1, l2, l1TTL, nil, "", logrus.New()), l1, l2
+}
+
+func TestTieredCacheGetFallsThroughToL2(t *testing.T) {
+	ctx := context.Background()
+	tiered, l1, l2 := newTestTieredCache(time.Minute)
+
+	_ = l2.Set(ctx, "key", "from-l2", 0)
+
+	value, ok, err := tiered.Get(ctx, "key")
+	if err != nil || !ok || value != "from-l2" {
+		t.Fatalf("expected from-l2, got %q ok=%v err=%v", value, ok, err)
+	}
+	if value, ok, _ := l1.Get(ctx, "key"); !ok || value != "from-l2" {
+		t.Fatalf("expected L2 value copied to L1, got %q ok=%v", value, ok)
+	}
+
+	_ = l1.Set(ctx, "key", "from-l1", 0)
+	if value, _, _ := tiered.Get(ctx, "key"); value != "from-l1" {
+		t.Fatalf("expected L1 to be read first, got %q", value)
+	}
+
+	if _, ok, _ := tiered.Get(ctx, "missing"); ok {
+		t.Fatalf("expected miss for unknown key")
+	}
+}
+
+func TestTieredCacheMGetMergesTiers(t *testing.T) {
+	ctx := context.Background()
+	tiered, l1, l2 := newTestTieredCache(time.Minute)
+
+	_ = l1.Set(ctx, "a", "l1-a", 0)
+	_ = l2.Set(ctx, "a", "l2-a", 0)
+	_ = l2.Set(ctx, "b", "l2-b", 0)
+
+	values, err := tiered.MGet(ctx, "a", "b", "c")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []string{"l1-a", "l2-b", ""}
+	if !reflect.DeepEqual(values, expected) {
+		t.Fatalf("expected %v, got %v", expected, values)
+	}
+
+	if value, ok, _ := l1.Get(ctx, "b"); !ok || value != "l2-b" {
+		t.Fatalf("expected fetched key copied to L1, got %q ok=%v", value, ok)
+	}
+	if _, ok, _ := l1.Get(ctx, "c"); ok {
+		t.Fatalf("expected missing key not cached in L1")
+	}
+}
+
+func TestTieredCacheCapsL1TTL(t *testing.T) {
+	ctx := context.Background()
+	tiered, l1, l2 := newTestTieredCache(time.Second)
+
+	testCases := []struct {
+		name  string
+		ttl   time.Duration
+		max   time.Duration
+		l2Min time.Duration
+	}{
+		{name: "longer_than_l1", ttl: time.Hour, max: time.Second, l2Min: 59 * time.Minute},
+		{name: "no_expiry", ttl: 0, max: time.Second},
+		{name: "shorter_than_l1", ttl: 100 * time.Millisecond, max: 100 * time.Millisecond},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := tiered.Set(ctx, tc.name, "value", tc.ttl); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			ttl, ok, _ := l1.TTL(ctx, tc.name)
+			if !ok || ttl <= 0 || ttl > tc.max {
+				t.Fatalf("expected L1 ttl in (0, %s], got %s ok=%v", tc.max, ttl, ok)
+			}
+
+			l2TTL, ok, _ := l2.TTL(ctx, tc.name)
+			if !ok || (tc.ttl == 0 && l2TTL != 0) || l2TTL < tc.l2Min {
+				t.Fatalf("expected L2 to keep ttl %s, got %s ok=%v", tc.ttl, l2TTL, ok)
+			}
+		})
+	}
+}
+
+func TestTieredCacheApply(t *testing.T) {
+	ctx := context.Background()
+	tiered, l1, l2 := newTestTieredCache(time.Minute)
+
+	for _, key := range []string{"products:date:2025-01-01", "products:date:2025-01-02", "report:version:all"} {
+		_ = l1.Set(ctx, key, "value", 0)
+		_ = l2.Set(ctx, key, "value", 0)
+	}
+
+	tiered.Apply(ctx, `{"origin":"other-replica","kind":"key","key":"report:version:all"}`)
+	if _, ok, _ := l1.Get(ctx, "report:version:all"); ok {
+		t.Fatalf("expected key invalidation to drop the L1 copy")
+	}
+	if _, ok, _ := l2.Get(ctx, "report:version:all"); !ok {
+		t.Fatalf("expected key invalidation to leave L2 untouched")
+	}
+
+	tiered.Apply(ctx, `{"origin":"other-replica","kind":"prefix","key":"products:date:"}`)
+	if l1.Len() != 0 {
+		t.Fatalf("expected prefix invalidation to empty L1, %d keys left", l1.Len())
+	}
+
+	_ = l1.Set(ctx, "key", "value", 0)
+	tiered.Apply(ctx, `not json`)
+	tiered.Apply(ctx, `{"origin":"other-replica","kind":"unknown","key":"key"}`)
+	if _, ok, _ := l1.Get(ctx, "key"); !ok {
+		t.Fatalf("expected malformed invalidations to be ignored")
+	}
+
+	if err := tiered.Close(); err != nil {
+		t.Fatalf("unexpected error closing a cache without subscription: %v", err)
+	}
+}